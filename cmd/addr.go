@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+
+	"caminoclient/internal/utils"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/spf13/cobra"
+)
+
+func addrCmd() *cobra.Command {
+	addrCmd := &cobra.Command{
+		Use:   "addr",
+		Short: "Address utilities",
+	}
+
+	convertCmd := &cobra.Command{
+		Use:   "convert <address|public key|private key>",
+		Short: "Print all address representations for network",
+		Long: `Print all address representations for network.
+Input can be short id (cb58), bech32 address with any chain prefix and hrp of network, avax hex short id,
+compressed or uncompressed public key (hex or cb58), private key (PrivateKey-... or hex) or evm 0x address.
+Hex private key and plain hex short id can't be detected, their format must be set with --from.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			networkID, err := cmd.Flags().GetUint32(networkIDFlag)
+			if err != nil {
+				return err
+			}
+			inputFormat, err := cmd.Flags().GetString(fromFlag)
+			if err != nil {
+				return err
+			}
+			formats, err := app.utils.ConvertAddress(args[0], utils.AddressInputFormat(inputFormat), networkID)
+			if err != nil {
+				return err
+			}
+			return app.print(formats)
+		}),
+	}
+	convertCmd.Flags().Uint32(networkIDFlag, constants.CaminoID, "network id used to format bech32 addresses")
+	convertCmd.Flags().String(fromFlag, string(utils.AddressInputAuto), "input format: auto, private-key, public-key, short-id, bech32 or evm")
+
+	nonceCmd := &cobra.Command{
+		Use:   "nonce <evm address>",
+		Short: "Print accepted and mempool C-Chain nonces of address",
+		Args:  cobra.ExactArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			addr, err := parseEVMAddress(args[0])
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			state, err := client.GetEVMNonceState(ctx, addr)
+			if err != nil {
+				return err
+			}
+			return app.print(state)
+		}),
+	}
+
+	addrCmd.AddCommand(convertCmd, nonceCmd)
+	return addrCmd
+}
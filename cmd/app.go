@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"os/user"
+
+	"caminoclient/internal/config"
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/logger"
+	"caminoclient/internal/utils"
+	"caminoclient/pkg/camino"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// app holds dependencies shared by all commands
+type app struct {
+	cfg       *config.Config
+	zapLogger *zap.SugaredLogger
+	logger    logger.Logger
+	utils     *utils.UtilsWithLogger
+}
+
+func newApp(ctx context.Context) (*app, error) {
+	// config isn't read yet, so it's read with default logger
+	bootstrapLogger, err := logger.NewZapLogger(&logger.Config{Level: "info", Format: logger.FormatConsole})
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := config.ReadConfig(ctx, bootstrapLogger)
+	_ = bootstrapLogger.Sync()
+	if err != nil {
+		return nil, err
+	}
+
+	zapLogger, err := logger.NewZapLogger(&logger.Config{
+		Level:      cfg.LogLevel,
+		Format:     cfg.LogFormat,
+		File:       cfg.LogFile,
+		MaxSize:    cfg.LogMaxSize,
+		MaxBackups: cfg.LogMaxBackups,
+		MaxAge:     cfg.LogMaxAge,
+	})
+	if err != nil {
+		return nil, errkind.Wrap(errkind.InvalidInput, err)
+	}
+
+	appLogger := logger.NewLoggerFromZap(zapLogger)
+	return &app{
+		cfg:       cfg,
+		zapLogger: zapLogger,
+		logger:    appLogger,
+		utils:     utils.NewUtils(appLogger),
+	}, nil
+}
+
+func (a *app) Close() {
+	_ = a.zapLogger.Sync()
+}
+
+func (a *app) nodeClient(ctx context.Context) (*camino.Client, error) {
+	cfg := &camino.Config{
+		URIs:         append([]string{a.cfg.NodeURI}, a.cfg.NodeFallbackURIs...),
+		Timeout:      a.cfg.RPCTimeout,
+		MaxRetries:   a.cfg.RPCRetries,
+		RetryBackoff: a.cfg.RPCRetryBackoff,
+		RateLimit:    a.cfg.RPCRateLimit,
+		Logger:       a.logger,
+	}
+	if a.cfg.SigningPolicy != "" {
+		signingPolicy, err := camino.LoadSigningPolicy(a.cfg.SigningPolicy)
+		if err != nil {
+			a.logger.Error(err)
+			return nil, err
+		}
+		cfg.SigningPolicy = signingPolicy
+	}
+	if a.cfg.AuditLog != "" {
+		auditLog, err := camino.OpenAuditLog(a.cfg.AuditLog, a.auditOperator())
+		if err != nil {
+			a.logger.Error(err)
+			return nil, err
+		}
+		cfg.AuditLog = auditLog
+	}
+	return camino.NewClient(ctx, cfg)
+}
+
+// auditOperator returns configured audit operator or os user name
+func (a *app) auditOperator() string {
+	if a.cfg.AuditOperator != "" {
+		return a.cfg.AuditOperator
+	}
+	if osUser, err := user.Current(); err == nil {
+		return osUser.Username
+	}
+	return ""
+}
+
+// runWithApp wraps command run func, providing it with initialized app
+func runWithApp(run func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		app, err := newApp(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer app.Close()
+		return run(cmd.Context(), app, cmd, args)
+	}
+}
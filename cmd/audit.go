@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"caminoclient/internal/audit"
+	"caminoclient/internal/errkind"
+
+	"github.com/spf13/cobra"
+)
+
+var errNoAuditLog = errkind.New(errkind.InvalidInput, "audit log path must be given as argument or audit_log config")
+
+func auditCmd() *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Hash-chained audit log of signed and issued txs, written if audit_log is configured",
+	}
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify [audit log]",
+		Short: "Verify, that audit log entries weren't changed, removed or reordered",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			path, err := auditLogPath(app, args)
+			if err != nil {
+				return err
+			}
+			verified, err := audit.Verify(path)
+			if err != nil {
+				app.logger.Errorf("verified %d entries before broken one", verified)
+				return err
+			}
+			return app.print(map[string]interface{}{"path": path, "entries": verified, "valid": true})
+		}),
+	}
+
+	exportCmd := &cobra.Command{
+		Use:   "export [audit log]",
+		Short: "Export audit log entries to stdout",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			path, err := auditLogPath(app, args)
+			if err != nil {
+				return err
+			}
+			format, err := cmd.Flags().GetString(formatFlag)
+			if err != nil {
+				return err
+			}
+			entries, err := audit.ReadEntries(path)
+			if err != nil {
+				return err
+			}
+			switch format {
+			case formatCSV:
+				return audit.WriteCSV(os.Stdout, entries)
+			case formatJSON:
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(entries)
+			}
+			return fmt.Errorf("%w: %s", errUnknownFormat, format)
+		}),
+	}
+	exportCmd.Flags().String(formatFlag, formatCSV, "export format: csv or json")
+
+	repairCmd := &cobra.Command{
+		Use:   "repair [audit log]",
+		Short: "Move torn last entry, which write was interrupted, to <audit log>" + audit.TornSuffix,
+		Long: `Move torn last entry, which write was interrupted, to <audit log>` + audit.TornSuffix + `.
+Log with torn entry can't be appended, complete entries aren't changed.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			path, err := auditLogPath(app, args)
+			if err != nil {
+				return err
+			}
+			torn, err := audit.Repair(path)
+			if err != nil {
+				return err
+			}
+			return app.print(map[string]interface{}{"path": path, "tornBytes": torn, "tornPath": path + audit.TornSuffix})
+		}),
+	}
+
+	auditCmd.AddCommand(verifyCmd, exportCmd, repairCmd)
+	return auditCmd
+}
+
+func auditLogPath(app *app, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if app.cfg.AuditLog == "" {
+		return "", errNoAuditLog
+	}
+	return app.cfg.AuditLog, nil
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/pkg/camino"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/spf13/cobra"
+)
+
+const (
+	signersFlag = "signers"
+	partialFlag = "partial"
+)
+
+var (
+	errAuthAddressRequired = errkind.New(errkind.InvalidInput, "authority address is required with several keys")
+	errNoAuthKeys          = errkind.New(errkind.InvalidInput, "authority keys or --"+signersFlag+" are required")
+	errPartialTx           = errkind.New(errkind.SignatureMissing, "tx isn't fully signed, use --"+partialFlag+" to save it for other signers")
+)
+
+// authorityFromFlags returns authority with address from addrFlag and keys from keysFlag,
+// keys default to defaultKey. Without address authority is its single key.
+// Alias owners, that will sign tx, are taken from --signers, if command has it.
+func authorityFromFlags(app *app, cmd *cobra.Command, addrFlag, keysFlag string, defaultKey *camino.PrivateKey) (*camino.Authority, error) {
+	keys, err := app.keysFromFlag(cmd, keysFlag)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 && defaultKey != nil {
+		keys = []camino.Signer{defaultKey}
+	}
+
+	var signers []ids.ShortID
+	if cmd.Flags().Lookup(signersFlag) != nil {
+		signerStrs, err := cmd.Flags().GetStringSlice(signersFlag)
+		if err != nil {
+			return nil, err
+		}
+		signers = make([]ids.ShortID, len(signerStrs))
+		for i, signerStr := range signerStrs {
+			signers[i], err = camino.ParseAddress(signerStr)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	addrStr, err := cmd.Flags().GetString(addrFlag)
+	if err != nil {
+		return nil, err
+	}
+	if addrStr == "" {
+		switch {
+		case len(keys) == 0:
+			return nil, errNoAuthKeys
+		case len(keys) > 1 || len(signers) > 0:
+			return nil, errAuthAddressRequired
+		}
+		return camino.KeyAuthority(keys[0]), nil
+	}
+	if len(keys) == 0 && len(signers) == 0 {
+		return nil, errNoAuthKeys
+	}
+	addr, err := camino.ParseAddress(addrStr)
+	if err != nil {
+		return nil, err
+	}
+	return &camino.Authority{Address: addr, Keys: keys, Signers: signers}, nil
+}
+
+// outputAuthTx issues fully signed tx, if issue is set, or saves partially signed tx
+// to partialPath, so other signers can add their signatures
+func outputAuthTx(ctx context.Context, app *app, cmd *cobra.Command, client *camino.Client, result *camino.PTxResult, issue bool, partialPath string) error {
+	if len(result.MissingSigners) > 0 {
+		if partialPath == "" {
+			return errPartialTx
+		}
+		partial, err := client.NewPartialPTx(result)
+		if err != nil {
+			return err
+		}
+		if err := partial.Write(partialPath); err != nil {
+			return err
+		}
+		return app.print(result)
+	}
+	if issue {
+		if err := app.confirmIssue(cmd, client, "P", result.Tx.Bytes()); err != nil {
+			return err
+		}
+		if err := client.IssuePTx(ctx, result.Tx.Bytes()); err != nil {
+			return err
+		}
+	}
+	return app.print(result)
+}
+
+func partialCmd() *cobra.Command {
+	partialCmd := &cobra.Command{
+		Use:   "partial",
+		Short: "Partially signed P-Chain txs, authorized by multisig aliases",
+	}
+
+	signCmd := &cobra.Command{
+		Use:   "sign <partial.json>",
+		Short: "Add signatures of keys to partially signed tx file",
+		Args:  cobra.ExactArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			partial, err := camino.ReadPartialPTx(args[0])
+			if err != nil {
+				return err
+			}
+			keys, err := app.keysFromFlag(cmd, keyFlag)
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			if err := client.SignPartialPTx(ctx, partial, keys); err != nil {
+				return err
+			}
+			if err := partial.Write(args[0]); err != nil {
+				return err
+			}
+			return app.print(partial)
+		}),
+	}
+	signCmd.Flags().StringSlice(keyFlag, nil, keyFlagUsage+", can be repeated")
+	_ = signCmd.MarkFlagRequired(keyFlag)
+
+	issueCmd := &cobra.Command{
+		Use:   "issue <partial.json>",
+		Short: "Issue tx from partially signed tx file, once all signers signed it",
+		Args:  cobra.ExactArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			partial, err := camino.ReadPartialPTx(args[0])
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			tx, err := client.SignedPTx(partial)
+			if err != nil {
+				return err
+			}
+			if err := app.confirmIssue(cmd, client, "P", tx.Bytes()); err != nil {
+				return err
+			}
+			if err := client.IssuePTx(ctx, tx.Bytes()); err != nil {
+				return err
+			}
+			return app.print(map[string]string{"txID": tx.ID().String()})
+		}),
+	}
+
+	partialCmd.AddCommand(signCmd, issueCmd)
+	return partialCmd
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+
+	"caminoclient/internal/batch"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	executorFlag    = "executor"
+	executorKeyFlag = "executor-key"
+	concurrencyFlag = "concurrency"
+	dryRunFlag      = "dry-run"
+	resultsFlag     = "results"
+)
+
+type batchSummary struct {
+	Rows      int    `json:"rows"`
+	Committed int    `json:"committed"`
+	Built     int    `json:"built"`
+	Issued    int    `json:"issued"`
+	Failed    int    `json:"failed"`
+	Results   string `json:"results"`
+}
+
+// pendingBatch describes rows, which txs are going to be issued
+type pendingBatch struct {
+	Rows           int            `json:"rows"`
+	Pending        map[string]int `json:"pendingByType"`
+	TransferAmount uint64         `json:"transferAmount"`
+}
+
+func pendingBatchSummary(rows []*batch.Row) *pendingBatch {
+	summary := &pendingBatch{Rows: len(rows), Pending: map[string]int{}}
+	for _, row := range rows {
+		if row.Status == batch.StatusCommitted {
+			continue
+		}
+		summary.Pending[row.Type]++
+		if row.Type == batch.RowTypeTransfer {
+			summary.TransferAmount += row.Amount
+		}
+	}
+	return summary
+}
+
+func batchCmd() *cobra.Command {
+	batchCmd := &cobra.Command{
+		Use:   "batch <rows.csv|rows.json>",
+		Short: "Build and issue P-Chain txs for every row of csv or json file",
+		Long: `Build and issue P-Chain txs for every row of csv or json file.
+Row columns: type (addressState or transfer), address, state (address state bit), remove, amount (nCAM).
+Txs are built one after another without waiting for acceptance, spending change of previous txs.
+Results file contains every row with its tx id, status and error; it can be used as input
+to re-run failed rows. Committed rows are skipped, rows with tx id are rebuilt only
+if node doesn't know their tx or rejected it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			rows, err := batch.ReadRows(args[0])
+			if err != nil {
+				return err
+			}
+			fundsKey, err := app.keyFromFlag(cmd, keyFlag)
+			if err != nil {
+				return err
+			}
+			executor, err := authorityFromFlags(app, cmd, executorFlag, executorKeyFlag, fundsKey)
+			if err != nil {
+				return err
+			}
+			concurrency, err := cmd.Flags().GetInt(concurrencyFlag)
+			if err != nil {
+				return err
+			}
+			dryRun, err := cmd.Flags().GetBool(dryRunFlag)
+			if err != nil {
+				return err
+			}
+			resultsPath, err := cmd.Flags().GetString(resultsFlag)
+			if err != nil {
+				return err
+			}
+			if resultsPath == "" {
+				resultsPath = batch.ResultsPath(args[0])
+			}
+
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			if !dryRun {
+				if err := app.confirm(cmd, client, pendingBatchSummary(rows)); err != nil {
+					return err
+				}
+			}
+			runErr := batch.Run(ctx, client, app.logger, rows, batch.Config{
+				FundsKey:    fundsKey,
+				Executor:    executor,
+				Concurrency: concurrency,
+				DryRun:      dryRun,
+			})
+			// results are written even if run was interrupted
+			if err := batch.WriteRows(resultsPath, rows); err != nil {
+				return err
+			}
+			if runErr != nil {
+				return runErr
+			}
+
+			summary := &batchSummary{Rows: len(rows), Results: resultsPath}
+			for _, row := range rows {
+				switch row.Status {
+				case batch.StatusCommitted:
+					summary.Committed++
+				case batch.StatusBuilt:
+					summary.Built++
+				case batch.StatusIssued:
+					summary.Issued++
+				case batch.StatusFailed:
+					summary.Failed++
+				}
+			}
+			return app.print(summary)
+		}),
+	}
+	batchCmd.Flags().String(keyFlag, "", keyFlagUsage+" (pays fees)")
+	batchCmd.Flags().String(executorFlag, "", "address state executor address, can be multisig alias, defaults to --"+executorKeyFlag+" address")
+	batchCmd.Flags().StringSlice(executorKeyFlag, nil, "executor or its alias owner keys, same formats as --"+keyFlag+", defaults to --"+keyFlag)
+	batchCmd.Flags().Int(concurrencyFlag, 4, "max number of txs issued at once")
+	batchCmd.Flags().Bool(dryRunFlag, false, "only build txs, don't issue them")
+	batchCmd.Flags().String(resultsFlag, "", "results file path, defaults to <input>.results.<ext>")
+	_ = batchCmd.MarkFlagRequired(keyFlag)
+	return batchCmd
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"caminoclient/pkg/camino"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const yesFlag = "yes"
+
+var (
+	errNotConfirmed   = errors.New("issuing wasn't confirmed")
+	errNonInteractive = fmt.Errorf("%w: production network requires confirmation, but stdin isn't terminal, use --%s", errNotConfirmed, yesFlag)
+)
+
+// confirmIssue shows summary of signed tx and asks to confirm issuing it, if client is connected to production network
+func (a *app) confirmIssue(cmd *cobra.Command, client *camino.Client, chain string, txBytes []byte) error {
+	if !client.IsProductionNetwork() {
+		return nil
+	}
+	summarize := client.SummarizePTx
+	if chain == "C" {
+		summarize = client.SummarizeCTx
+	}
+	summary, err := summarize(txBytes)
+	if err != nil {
+		return err
+	}
+	return a.confirm(cmd, client, summary)
+}
+
+// confirm shows summary of what is going to be issued on production network and asks
+// to type network name to confirm it, unless --yes is set. Without terminal it refuses.
+// Other networks don't need confirmation.
+func (a *app) confirm(cmd *cobra.Command, client *camino.Client, summary interface{}) error {
+	if !client.IsProductionNetwork() {
+		return nil
+	}
+	networkName := constants.NetworkName(client.NetworkID())
+	fmt.Fprintf(os.Stderr, "\nIssuing on production network %s:\n\n", networkName)
+	if err := render(os.Stderr, outputText, summary); err != nil {
+		return err
+	}
+	yes, err := cmd.Flags().GetBool(yesFlag)
+	if err != nil {
+		return err
+	}
+	if yes {
+		return nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return errNonInteractive
+	}
+	fmt.Fprintf(os.Stderr, "\nType %q to confirm: ", networkName)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return errNotConfirmed
+	}
+	if strings.TrimSpace(answer) != networkName {
+		return errNotConfirmed
+	}
+	return nil
+}
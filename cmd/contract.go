@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"caminoclient/internal/deployments"
+	"caminoclient/pkg/camino"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+const contractNameFlag = "contract"
+
+// contractDeploySummary describes contract creation tx, that is going to be sent
+type contractDeploySummary struct {
+	Contract string         `json:"contract"`
+	Deployer common.Address `json:"deployer"`
+	Args     []string       `json:"constructorArgs"`
+	Gas      uint64         `json:"gas"`
+	MaxFee   *big.Int       `json:"maxFee"` // wei
+}
+
+func contractCmd() *cobra.Command {
+	contractCmd := &cobra.Command{
+		Use:   "contract",
+		Short: "C-Chain contract operations",
+	}
+
+	deployCmd := &cobra.Command{
+		Use:   "deploy <artifact.json> [constructor args...]",
+		Short: "Deploy contract from solc, Hardhat or Foundry artifact and record it in deployments registry",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			contractName, err := cmd.Flags().GetString(contractNameFlag)
+			if err != nil {
+				return err
+			}
+			artifact, err := camino.ReadContractArtifact(args[0], contractName)
+			if err != nil {
+				app.logger.Error(err)
+				return err
+			}
+			constructorArgs, err := camino.ParseABIArgs(artifact.ABI.Constructor.Inputs, args[1:])
+			if err != nil {
+				app.logger.Error(err)
+				return err
+			}
+			key, err := app.keyFromFlag(cmd, keyFlag)
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			registry, err := deployments.Load(app.cfg.DeploymentsDir, client.HRP())
+			if err != nil {
+				app.logger.Error(err)
+				return err
+			}
+
+			if client.IsProductionNetwork() {
+				deployer := camino.EVMAddress(key)
+				txEstimate, err := client.EstimateContractDeploy(ctx, artifact, constructorArgs, deployer)
+				if err != nil {
+					return err
+				}
+				if err := app.confirm(cmd, client, &contractDeploySummary{
+					Contract: artifact.Name,
+					Deployer: deployer,
+					Args:     args[1:],
+					Gas:      txEstimate.Gas,
+					MaxFee:   txEstimate.MaxFee,
+				}); err != nil {
+					return err
+				}
+			}
+
+			deployment, err := client.DeployContract(ctx, artifact, constructorArgs, key)
+			if err != nil {
+				return err
+			}
+
+			if err := registry.Add(&deployments.Deployment{
+				Name:        deployment.Name,
+				Address:     deployment.Address.Hex(),
+				TxHash:      deployment.TxHash.Hex(),
+				Deployer:    deployment.Deployer.Hex(),
+				BlockNumber: deployment.Receipt.BlockNumber.Uint64(),
+				Artifact:    args[0],
+				Timestamp:   time.Now().UTC(),
+			}); err != nil {
+				app.logger.Error(err)
+				return err
+			}
+			return app.print(deployment)
+		}),
+	}
+	deployCmd.Flags().String(keyFlag, "", keyFlagUsage)
+	deployCmd.Flags().String(contractNameFlag, "", "contract name, if artifact contains several contracts")
+	_ = deployCmd.MarkFlagRequired(keyFlag)
+
+	contractCmd.AddCommand(deployCmd)
+	return contractCmd
+}
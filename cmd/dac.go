@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/spf13/cobra"
+)
+
+const (
+	voterFlag    = "voter"
+	voterKeyFlag = "voter-key"
+)
+
+func dacCmd() *cobra.Command {
+	dacCmd := &cobra.Command{
+		Use:   "dac",
+		Short: "P-Chain dac proposals",
+	}
+
+	voteCmd := &cobra.Command{
+		Use:   "vote <proposal id> <option index>",
+		Short: "Vote for proposal option",
+		Long: `Vote for proposal option.
+Voter is --voter address, which can be consortium member multisig alias, or --voter-key address.
+Alias votes are signed by its owner keys from --voter-key. If they don't reach alias threshold,
+--signers must list threshold owners, tx is saved to --partial file and other owners add their
+signatures with "partial sign", then it's issued with "partial issue".`,
+		Args: cobra.ExactArgs(2),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			proposalID, err := ids.FromString(args[0])
+			if err != nil {
+				return err
+			}
+			optionIndex, err := strconv.ParseUint(args[1], 10, 32)
+			if err != nil {
+				return err
+			}
+			fundsKey, err := app.keyFromFlag(cmd, keyFlag)
+			if err != nil {
+				return err
+			}
+			voter, err := authorityFromFlags(app, cmd, voterFlag, voterKeyFlag, fundsKey)
+			if err != nil {
+				return err
+			}
+			issue, err := cmd.Flags().GetBool(issueFlag)
+			if err != nil {
+				return err
+			}
+			partialPath, err := cmd.Flags().GetString(partialFlag)
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			result, err := client.VoteTx(ctx, proposalID, uint32(optionIndex), fundsKey, voter)
+			if err != nil {
+				return err
+			}
+			return outputAuthTx(ctx, app, cmd, client, result, issue, partialPath)
+		}),
+	}
+	voteCmd.Flags().String(keyFlag, "", keyFlagUsage+" (pays fee)")
+	voteCmd.Flags().String(voterFlag, "", "voter address, can be multisig alias, defaults to --"+voterKeyFlag+" address")
+	voteCmd.Flags().StringSlice(voterKeyFlag, nil, "voter or its alias owner keys, same formats as --"+keyFlag+", defaults to --"+keyFlag)
+	voteCmd.Flags().StringSlice(signersFlag, nil, "alias owners, that sign tx, if not all their keys are given")
+	voteCmd.Flags().String(partialFlag, "", "file to save partially signed tx to")
+	voteCmd.Flags().Bool(issueFlag, false, "issue tx after building it")
+	_ = voteCmd.MarkFlagRequired(keyFlag)
+
+	dacCmd.AddCommand(voteCmd)
+	return dacCmd
+}
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/pkg/camino"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+const keyFlag = "key"
+
+var errInvalidEVMAddress = errkind.New(errkind.InvalidInput, "invalid evm address")
+
+func erc20Cmd() *cobra.Command {
+	erc20Cmd := &cobra.Command{
+		Use:   "erc20",
+		Short: "C-Chain erc20 token operations",
+	}
+
+	infoCmd := &cobra.Command{
+		Use:  "info <token>",
+		Args: cobra.ExactArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			token, err := parseEVMAddress(args[0])
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			info, err := client.ERC20Info(ctx, token)
+			if err != nil {
+				return err
+			}
+			return app.print(info)
+		}),
+	}
+
+	balanceCmd := &cobra.Command{
+		Use:  "balance <token> <owner>",
+		Args: cobra.ExactArgs(2),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			addrs, err := parseEVMAddresses(args)
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			decimals, err := client.ERC20Decimals(ctx, addrs[0])
+			if err != nil {
+				return err
+			}
+			balance, err := client.ERC20Balance(ctx, addrs[0], addrs[1])
+			if err != nil {
+				return err
+			}
+			return app.print(erc20Amount(balance, decimals))
+		}),
+	}
+
+	allowanceCmd := &cobra.Command{
+		Use:  "allowance <token> <owner> <spender>",
+		Args: cobra.ExactArgs(3),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			addrs, err := parseEVMAddresses(args)
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			decimals, err := client.ERC20Decimals(ctx, addrs[0])
+			if err != nil {
+				return err
+			}
+			allowance, err := client.ERC20Allowance(ctx, addrs[0], addrs[1], addrs[2])
+			if err != nil {
+				return err
+			}
+			return app.print(erc20Amount(allowance, decimals))
+		}),
+	}
+
+	transferCmd := &cobra.Command{
+		Use:   "transfer <token> <to> <amount> [<to> <amount>...]",
+		Short: "Transfer tokens to one or more recipients, sending txs one after another",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 3 || len(args)%2 != 1 {
+				return errkind.New(errkind.InvalidInput, "expected token followed by one or more <to> <amount> pairs")
+			}
+			return nil
+		},
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			return runERC20Send(ctx, app, cmd, args, (*camino.Client).EstimateERC20Transfer, (*camino.Client).ERC20Transfer)
+		}),
+	}
+
+	approveCmd := &cobra.Command{
+		Use:  "approve <token> <spender> <amount>",
+		Args: cobra.ExactArgs(3),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			return runERC20Send(ctx, app, cmd, args, (*camino.Client).EstimateERC20Approve, (*camino.Client).ERC20Approve)
+		}),
+	}
+
+	for _, cmd := range []*cobra.Command{transferCmd, approveCmd} {
+		cmd.Flags().String(keyFlag, "", keyFlagUsage)
+		_ = cmd.MarkFlagRequired(keyFlag)
+	}
+
+	erc20Cmd.AddCommand(infoCmd, balanceCmd, allowanceCmd, transferCmd, approveCmd)
+	return erc20Cmd
+}
+
+type erc20SendFunc func(
+	client *camino.Client,
+	ctx context.Context,
+	token, to common.Address,
+	amount *big.Int,
+	key camino.Signer,
+) (*types.Transaction, error)
+
+type erc20EstimateFunc func(
+	client *camino.Client,
+	ctx context.Context,
+	token, from, to common.Address,
+	amount *big.Int,
+) (*camino.EVMTxEstimate, error)
+
+// erc20SendSummary describes erc20 txs, that are going to be sent
+type erc20SendSummary struct {
+	Token  string            `json:"token"`
+	From   common.Address    `json:"from"`
+	Txs    []*erc20TxSummary `json:"txs"`
+	MaxFee *big.Int          `json:"maxFee"` // wei, sum of all txs
+}
+
+type erc20TxSummary struct {
+	To     common.Address `json:"to"`
+	Amount string         `json:"amount"`
+	Gas    uint64         `json:"gas"`
+}
+
+// runERC20Send sends one tx per <to> <amount> pair and only then waits for their receipts
+func runERC20Send(
+	ctx context.Context,
+	app *app,
+	cmd *cobra.Command,
+	args []string,
+	estimate erc20EstimateFunc,
+	send erc20SendFunc,
+) error {
+	token, err := parseEVMAddress(args[0])
+	if err != nil {
+		return err
+	}
+	key, err := app.keyFromFlag(cmd, keyFlag)
+	if err != nil {
+		return err
+	}
+	client, err := app.nodeClient(ctx)
+	if err != nil {
+		return err
+	}
+	decimals, err := client.ERC20Decimals(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	// all pairs are validated before sending, so malformed pair doesn't leave part of txs sent
+	recipients := make([]common.Address, 0, len(args)/2)
+	amounts := make([]*big.Int, 0, len(args)/2)
+	for i := 1; i < len(args); i += 2 {
+		to, err := parseEVMAddress(args[i])
+		if err != nil {
+			return err
+		}
+		amount, err := camino.ParseAmount(args[i+1], decimals)
+		if err != nil {
+			return err
+		}
+		recipients = append(recipients, to)
+		amounts = append(amounts, amount)
+	}
+
+	if client.IsProductionNetwork() {
+		summary, err := summarizeERC20Send(ctx, client, token, camino.EVMAddress(key), recipients, amounts, estimate)
+		if err != nil {
+			return err
+		}
+		if err := app.confirm(cmd, client, summary); err != nil {
+			return err
+		}
+	}
+
+	txs := make([]*types.Transaction, 0, len(recipients))
+	for i, to := range recipients {
+		tx, err := send(client, ctx, token, to, amounts[i], key)
+		if err != nil {
+			return err
+		}
+		txs = append(txs, tx)
+	}
+
+	results := make([]*camino.ERC20TxResult, len(txs))
+	for i, tx := range txs {
+		results[i], err = client.WaitForERC20TxResult(ctx, token, tx.Hash())
+		if err != nil {
+			return err
+		}
+	}
+	return app.print(results)
+}
+
+func summarizeERC20Send(
+	ctx context.Context,
+	client *camino.Client,
+	token, from common.Address,
+	recipients []common.Address,
+	amounts []*big.Int,
+	estimate erc20EstimateFunc,
+) (*erc20SendSummary, error) {
+	info, err := client.ERC20Info(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	summary := &erc20SendSummary{
+		Token:  fmt.Sprintf("%s (%s)", info.Symbol, token.Hex()),
+		From:   from,
+		Txs:    make([]*erc20TxSummary, len(recipients)),
+		MaxFee: new(big.Int),
+	}
+	for i, to := range recipients {
+		txEstimate, err := estimate(client, ctx, token, from, to, amounts[i])
+		if err != nil {
+			return nil, err
+		}
+		summary.Txs[i] = &erc20TxSummary{
+			To:     to,
+			Amount: camino.FormatAmount(amounts[i], info.Decimals),
+			Gas:    txEstimate.Gas,
+		}
+		summary.MaxFee.Add(summary.MaxFee, txEstimate.MaxFee)
+	}
+	return summary, nil
+}
+
+type erc20AmountJSON struct {
+	Amount   *big.Int `json:"amount"`
+	Decimal  string   `json:"decimal"`
+	Decimals uint8    `json:"decimals"`
+}
+
+func erc20Amount(amount *big.Int, decimals uint8) *erc20AmountJSON {
+	return &erc20AmountJSON{
+		Amount:   amount,
+		Decimal:  camino.FormatAmount(amount, decimals),
+		Decimals: decimals,
+	}
+}
+
+func parseEVMAddress(addrStr string) (common.Address, error) {
+	if !common.IsHexAddress(addrStr) {
+		return common.Address{}, fmt.Errorf("%w: %q", errInvalidEVMAddress, addrStr)
+	}
+	return common.HexToAddress(addrStr), nil
+}
+
+func parseEVMAddresses(addrStrs []string) ([]common.Address, error) {
+	addrs := make([]common.Address, len(addrStrs))
+	for i, addrStr := range addrStrs {
+		addr, err := parseEVMAddress(addrStr)
+		if err != nil {
+			return nil, err
+		}
+		addrs[i] = addr
+	}
+	return addrs, nil
+}
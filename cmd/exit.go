@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"caminoclient/internal/config"
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/policy"
+)
+
+// Exit codes of failed commands. They are stable, so that scripts can react to specific failures.
+const (
+	exitOK                = 0
+	exitError             = 1
+	exitInvalidInput      = 2
+	exitInsufficientFunds = 3
+	exitWrongNetwork      = 4
+	exitRPCUnavailable    = 5
+	exitTxRejected        = 6
+	exitSignatureMissing  = 7
+	exitPolicyDenied      = 8
+	exitNotConfirmed      = 9
+	exitInterrupted       = 130
+)
+
+// Kinds of command errors, that aren't returned by node
+const (
+	kindInterrupted  errkind.Kind = "interrupted"
+	kindPolicyDenied errkind.Kind = "policy_denied"
+	kindNotConfirmed errkind.Kind = "not_confirmed"
+)
+
+var kindExitCodes = map[errkind.Kind]int{
+	errkind.InvalidInput:      exitInvalidInput,
+	errkind.InsufficientFunds: exitInsufficientFunds,
+	errkind.WrongNetwork:      exitWrongNetwork,
+	errkind.RPCUnavailable:    exitRPCUnavailable,
+	errkind.TxRejected:        exitTxRejected,
+	errkind.SignatureMissing:  exitSignatureMissing,
+	kindPolicyDenied:          exitPolicyDenied,
+	kindNotConfirmed:          exitNotConfirmed,
+	kindInterrupted:           exitInterrupted,
+}
+
+// errorOutput is command error, that is written to stderr
+type errorOutput struct {
+	Error    string       `json:"error"`
+	Kind     errkind.Kind `json:"kind"`
+	ExitCode int          `json:"exitCode"`
+	// Reason of tx rejection by node
+	Reason string `json:"reason,omitempty"`
+	// Reasons of signing denied by policy
+	Reasons []*policy.Reason `json:"reasons,omitempty"`
+}
+
+// errorKind returns kind of command error
+func errorKind(err error) errkind.Kind {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return kindInterrupted
+	case errors.Is(err, policy.ErrDenied):
+		return kindPolicyDenied
+	case errors.Is(err, errNotConfirmed):
+		return kindNotConfirmed
+	}
+	return errkind.Of(err)
+}
+
+// writeError writes command error to stderr in configured output format and returns its exit code
+func writeError(err error) int {
+	kind := errorKind(err)
+	code, ok := kindExitCodes[kind]
+	if !ok {
+		code = exitError
+	}
+	output := &errorOutput{
+		Error:    err.Error(),
+		Kind:     kind,
+		ExitCode: code,
+		Reason:   errkind.ReasonOf(err),
+	}
+	var denial *policy.Denial
+	if errors.As(err, &denial) {
+		output.Reasons = denial.Reasons
+	}
+	format := config.OutputFormat()
+	if format != outputJSON && format != outputYAML {
+		format = outputText
+	}
+	if renderErr := render(os.Stderr, format, output); renderErr != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	return code
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"caminoclient/internal/wallet"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	countFlag    = "count"
+	gapLimitFlag = "gap-limit"
+	bitsFlag     = "bits"
+)
+
+func hdCmd() *cobra.Command {
+	hdCmd := &cobra.Command{
+		Use:   "hd",
+		Short: "HD wallet (bip-39/bip-44), mnemonic is read from " + mnemonicEnv + " env var",
+	}
+
+	newCmd := &cobra.Command{
+		Use:   "new",
+		Short: "Generate new mnemonic",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bits, err := cmd.Flags().GetInt(bitsFlag)
+			if err != nil {
+				return err
+			}
+			mnemonic, err := wallet.NewMnemonic(bits)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), mnemonic)
+			return err
+		},
+	}
+	newCmd.Flags().Int(bitsFlag, wallet.DefaultMnemonicBits, "entropy bits: 128 (12 words) to 256 (24 words)")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List first derived accounts with balances",
+		Args:  cobra.NoArgs,
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			count, err := cmd.Flags().GetUint32(countFlag)
+			if err != nil {
+				return err
+			}
+			hdWallet, err := hdWalletFromEnv()
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			accounts, err := client.ListHDAccounts(ctx, hdWallet, count)
+			if err != nil {
+				return err
+			}
+			return app.print(accounts)
+		}),
+	}
+	listCmd.Flags().Uint32(countFlag, 5, "number of accounts")
+
+	discoverCmd := &cobra.Command{
+		Use:   "discover",
+		Short: "Discover used accounts with gap limit",
+		Args:  cobra.NoArgs,
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			gapLimit, err := cmd.Flags().GetUint32(gapLimitFlag)
+			if err != nil {
+				return err
+			}
+			hdWallet, err := hdWalletFromEnv()
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			accounts, err := client.DiscoverHDAccounts(ctx, hdWallet, gapLimit)
+			if err != nil {
+				return err
+			}
+			return app.print(accounts)
+		}),
+	}
+	discoverCmd.Flags().Uint32(gapLimitFlag, 20, "number of consecutive unused accounts after which discovery stops")
+
+	hdCmd.AddCommand(newCmd, listCmd, discoverCmd)
+	return hdCmd
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/wallet"
+	"caminoclient/pkg/camino"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	mnemonicEnv           = "CAMINO_MNEMONIC"
+	mnemonicPassphraseEnv = "CAMINO_MNEMONIC_PASSPHRASE"
+
+	hdKeyPrefix    = "hd:"
+	hdEVMKeyPrefix = "hd-evm:"
+
+	keyFlagUsage = "signer key: PrivateKey-..., hd:<index> (P-Chain path), hd-evm:<index> (C-Chain path) or hd:m/44'/... with mnemonic from " + mnemonicEnv
+)
+
+var errNoMnemonic = errkind.New(errkind.InvalidInput, mnemonicEnv+" env var must be set to use hd keys")
+
+// keyFromFlag resolves signer key from flag value, which is either private key
+// or key derived from hd wallet mnemonic (see keyFlagUsage)
+func (a *app) keyFromFlag(cmd *cobra.Command, flag string) (*camino.PrivateKey, error) {
+	keyStr, err := cmd.Flags().GetString(flag)
+	if err != nil {
+		return nil, err
+	}
+	return a.parseKey(keyStr)
+}
+
+// keysFromFlag resolves signer keys from string slice flag, see keyFromFlag
+func (a *app) keysFromFlag(cmd *cobra.Command, flag string) ([]camino.Signer, error) {
+	keyStrs, err := cmd.Flags().GetStringSlice(flag)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]camino.Signer, len(keyStrs))
+	for i, keyStr := range keyStrs {
+		keys[i], err = a.parseKey(keyStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+func (a *app) parseKey(keyStr string) (*camino.PrivateKey, error) {
+	var path string
+	switch {
+	case strings.HasPrefix(keyStr, hdKeyPrefix+"m/"):
+		path = strings.TrimPrefix(keyStr, hdKeyPrefix)
+	case strings.HasPrefix(keyStr, hdKeyPrefix), strings.HasPrefix(keyStr, hdEVMKeyPrefix):
+		isEVM := strings.HasPrefix(keyStr, hdEVMKeyPrefix)
+		indexStr := strings.TrimPrefix(strings.TrimPrefix(keyStr, hdEVMKeyPrefix), hdKeyPrefix)
+		index, err := strconv.ParseUint(indexStr, 10, 31)
+		if err != nil {
+			err := fmt.Errorf("invalid hd key index %q: %w", indexStr, err)
+			a.logger.Error(err)
+			return nil, err
+		}
+		path = wallet.AvaxPath(uint32(index))
+		if isEVM {
+			path = wallet.EVMPath(uint32(index))
+		}
+	default:
+		return camino.ParsePrivateKey(keyStr)
+	}
+
+	hdWallet, err := hdWalletFromEnv()
+	if err != nil {
+		a.logger.Error(err)
+		return nil, err
+	}
+	key, err := hdWallet.DeriveKey(path)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, err
+	}
+	a.logger.Debugf("using hd key %s", path)
+	return key, nil
+}
+
+func hdWalletFromEnv() (*wallet.HDWallet, error) {
+	mnemonic := os.Getenv(mnemonicEnv)
+	if mnemonic == "" {
+		return nil, errNoMnemonic
+	}
+	return wallet.NewHDWallet(mnemonic, os.Getenv(mnemonicPassphraseEnv))
+}
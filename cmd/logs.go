@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/pkg/camino"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	addressFlag   = "address"
+	eventFlag     = "event"
+	topicFlagFmt  = "topic%d"
+	fromBlockFlag = "from-block"
+	toBlockFlag   = "to-block"
+	chunkSizeFlag = "chunk-size"
+	abiFlag       = "abi"
+	formatFlag    = "format"
+
+	formatJSONL = "jsonl"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+var (
+	errEventWithoutABI = errkind.New(errkind.InvalidInput, "--event requires --abi")
+	errUnknownFormat   = errkind.New(errkind.InvalidInput, "unknown output format")
+	errInvalidTopic    = errkind.New(errkind.InvalidInput, "invalid topic")
+)
+
+func logsCmd() *cobra.Command {
+	logsCmd := &cobra.Command{
+		Use:   "logs",
+		Short: "C-Chain event logs",
+	}
+
+	queryCmd := &cobra.Command{
+		Use:   "query",
+		Short: "Query and decode C-Chain event logs",
+		Args:  cobra.NoArgs,
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			addrStrs, _ := flags.GetStringSlice(addressFlag)
+			eventName, _ := flags.GetString(eventFlag)
+			fromBlock, _ := flags.GetUint64(fromBlockFlag)
+			toBlockStr, _ := flags.GetString(toBlockFlag)
+			chunkSize, _ := flags.GetUint64(chunkSizeFlag)
+			abiPath, _ := flags.GetString(abiFlag)
+			contractName, _ := flags.GetString(contractNameFlag)
+			format, _ := flags.GetString(formatFlag)
+
+			addrs, err := parseEVMAddresses(addrStrs)
+			if err != nil {
+				return err
+			}
+
+			var contractABI *abi.ABI
+			if abiPath != "" {
+				contractABI, err = camino.ReadContractABI(abiPath, contractName)
+				if err != nil {
+					app.logger.Error(err)
+					return err
+				}
+			}
+
+			topics := make([][]common.Hash, 4)
+			for i := range topics {
+				topicStrs, _ := flags.GetStringSlice(fmt.Sprintf(topicFlagFmt, i))
+				for _, topicStr := range topicStrs {
+					topicBytes, err := hexutil.Decode(topicStr)
+					if err != nil || len(topicBytes) > common.HashLength {
+						return fmt.Errorf("%w: %q", errInvalidTopic, topicStr)
+					}
+					topics[i] = append(topics[i], common.BytesToHash(topicBytes))
+				}
+			}
+
+			var event *abi.Event
+			if eventName != "" {
+				if contractABI == nil {
+					return errEventWithoutABI
+				}
+				abiEvent, ok := contractABI.Events[eventName]
+				if !ok {
+					return fmt.Errorf("%w: %s", camino.ErrUnknownEvent, eventName)
+				}
+				event = &abiEvent
+				topics[0] = []common.Hash{event.ID}
+			}
+			for len(topics) > 0 && len(topics[len(topics)-1]) == 0 {
+				topics = topics[:len(topics)-1]
+			}
+
+			query := interfaces.FilterQuery{
+				FromBlock: new(big.Int).SetUint64(fromBlock),
+				Addresses: addrs,
+				Topics:    topics,
+			}
+			if toBlockStr != "latest" {
+				toBlock, err := strconv.ParseUint(toBlockStr, 10, 64)
+				if err != nil {
+					return err
+				}
+				query.ToBlock = new(big.Int).SetUint64(toBlock)
+			}
+
+			var writeLog func(*logRecord) error
+			switch format {
+			case formatJSONL:
+				encoder := json.NewEncoder(os.Stdout)
+				writeLog = func(record *logRecord) error { return encoder.Encode(record) }
+			case formatCSV:
+				csvWriter := newLogsCSVWriter(event)
+				if err := csvWriter.writeHeader(); err != nil {
+					return err
+				}
+				defer csvWriter.Flush()
+				writeLog = csvWriter.write
+			default:
+				return fmt.Errorf("%w: %s", errUnknownFormat, format)
+			}
+
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			return client.FilterLogsChunked(ctx, query, chunkSize, func(logs []types.Log) error {
+				for i := range logs {
+					record, err := newLogRecord(contractABI, &logs[i])
+					if err != nil {
+						return err
+					}
+					if err := writeLog(record); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		}),
+	}
+	flags := queryCmd.Flags()
+	flags.StringSlice(addressFlag, nil, "contract addresses")
+	flags.String(eventFlag, "", "event name from abi, sets topic0")
+	for i := 0; i < 4; i++ {
+		flags.StringSlice(fmt.Sprintf(topicFlagFmt, i), nil, fmt.Sprintf("topic %d values (any of)", i))
+	}
+	flags.Uint64(fromBlockFlag, 0, "first block of range")
+	flags.String(toBlockFlag, "latest", "last block of range")
+	flags.Uint64(chunkSizeFlag, 2048, "max number of blocks queried at once")
+	flags.String(abiFlag, "", "path to abi json or contract artifact used to decode events")
+	flags.String(contractNameFlag, "", "contract name, if artifact contains several contracts")
+	flags.String(formatFlag, formatJSONL, "output format: jsonl or csv")
+
+	logsCmd.AddCommand(queryCmd)
+	return logsCmd
+}
+
+type logRecord struct {
+	BlockNumber uint64                 `json:"blockNumber"`
+	TxHash      common.Hash            `json:"txHash"`
+	LogIndex    uint                   `json:"logIndex"`
+	Address     common.Address         `json:"address"`
+	Event       string                 `json:"event,omitempty"`
+	Fields      map[string]interface{} `json:"fields,omitempty"`
+	Topics      []common.Hash          `json:"topics,omitempty"`
+	Data        hexutil.Bytes          `json:"data,omitempty"`
+}
+
+// newLogRecord decodes log with abi if possible, otherwise keeps raw topics and data
+func newLogRecord(contractABI *abi.ABI, log *types.Log) (*logRecord, error) {
+	record := &logRecord{
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+		LogIndex:    log.Index,
+		Address:     log.Address,
+	}
+	if contractABI != nil {
+		event, err := camino.DecodeEVMLog(contractABI, log)
+		switch {
+		case err == nil:
+			record.Event = event.Name
+			record.Fields = make(map[string]interface{}, len(event.Fields))
+			for name, value := range event.Fields {
+				record.Fields[name] = formatABIValue(value)
+			}
+			return record, nil
+		case !errors.Is(err, camino.ErrUnknownEvent):
+			return nil, err
+		}
+	}
+	record.Topics = log.Topics
+	record.Data = log.Data
+	return record, nil
+}
+
+// formatABIValue converts byte arrays and slices into hex
+func formatABIValue(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(bytes), v)
+			return hexutil.Bytes(bytes)
+		}
+	}
+	return value
+}
+
+type logsCSVWriter struct {
+	*csv.Writer
+	event *abi.Event
+}
+
+func newLogsCSVWriter(event *abi.Event) *logsCSVWriter {
+	return &logsCSVWriter{Writer: csv.NewWriter(os.Stdout), event: event}
+}
+
+func (w *logsCSVWriter) writeHeader() error {
+	header := []string{"block_number", "tx_hash", "log_index", "address", "event"}
+	if w.event != nil {
+		for _, input := range w.event.Inputs {
+			header = append(header, input.Name)
+		}
+	} else {
+		header = append(header, "fields")
+	}
+	return w.Write(append(header, "topics", "data"))
+}
+
+func (w *logsCSVWriter) write(record *logRecord) error {
+	row := []string{
+		strconv.FormatUint(record.BlockNumber, 10),
+		record.TxHash.Hex(),
+		strconv.FormatUint(uint64(record.LogIndex), 10),
+		record.Address.Hex(),
+		record.Event,
+	}
+	if w.event != nil {
+		for _, input := range w.event.Inputs {
+			row = append(row, csvValue(record.Fields[input.Name]))
+		}
+	} else if record.Fields != nil {
+		fieldsJSON, err := json.Marshal(record.Fields)
+		if err != nil {
+			return err
+		}
+		row = append(row, string(fieldsJSON))
+	} else {
+		row = append(row, "")
+	}
+
+	topics := make([]string, len(record.Topics))
+	for i, topic := range record.Topics {
+		topics[i] = topic.Hex()
+	}
+	data := ""
+	if len(record.Data) > 0 {
+		data = record.Data.String()
+	}
+	return w.Write(append(row, strings.Join(topics, " "), data))
+}
+
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"caminoclient/internal/signer"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	hashModeFlag  = "mode"
+	hexMsgFlag    = "hex"
+	networkIDFlag = "network-id"
+)
+
+var errSignerMismatch = errors.New("signature signer doesn't match expected address")
+
+func msgCmd() *cobra.Command {
+	msgCmd := &cobra.Command{
+		Use:   "msg",
+		Short: "Message signing and signature verification",
+	}
+
+	signCmd := &cobra.Command{
+		Use:  "sign <message>",
+		Args: cobra.ExactArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			msg, mode, networkID, err := msgArgs(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			key, err := app.keyFromFlag(cmd, keyFlag)
+			if err != nil {
+				return err
+			}
+			sig, err := signer.NewMsgSignerFromKey(key, app.logger).SignWithHashMode(msg, mode)
+			if err != nil {
+				return err
+			}
+			recoveredSigner, err := signer.NewMsgVerifier(app.logger).Recover(msg, sig, mode, networkID)
+			if err != nil {
+				return err
+			}
+			sigCB58, err := formatting.Encode(formatting.CB58, sig)
+			if err != nil {
+				return err
+			}
+			return app.print(&struct {
+				Mode          signer.HashMode         `json:"mode"`
+				Message       hexutil.Bytes           `json:"message"`
+				Signature     hexutil.Bytes           `json:"signature"`
+				SignatureCB58 string                  `json:"signatureCB58"`
+				Signer        *signer.RecoveredSigner `json:"signer"`
+			}{
+				Mode:          mode,
+				Message:       msg,
+				Signature:     sig,
+				SignatureCB58: sigCB58,
+				Signer:        recoveredSigner,
+			})
+		}),
+	}
+	signCmd.Flags().String(keyFlag, "", keyFlagUsage)
+	_ = signCmd.MarkFlagRequired(keyFlag)
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify <message> <signature>",
+		Short: "Recover message signer and optionally check it against expected address",
+		Args:  cobra.ExactArgs(2),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			msg, mode, networkID, err := msgArgs(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			sig, err := signer.DecodeSignature(args[1])
+			if err != nil {
+				return err
+			}
+			expectedAddr, err := cmd.Flags().GetString(addressFlag)
+			if err != nil {
+				return err
+			}
+
+			verifier := signer.NewMsgVerifier(app.logger)
+			if expectedAddr == "" {
+				recoveredSigner, err := verifier.Recover(msg, sig, mode, networkID)
+				if err != nil {
+					return err
+				}
+				return app.print(recoveredSigner)
+			}
+
+			recoveredSigner, ok, err := verifier.Verify(msg, sig, mode, expectedAddr, networkID)
+			if err != nil {
+				return err
+			}
+			if err := app.print(&struct {
+				*signer.RecoveredSigner
+				Valid bool `json:"valid"`
+			}{recoveredSigner, ok}); err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("%w: %s", errSignerMismatch, expectedAddr)
+			}
+			return nil
+		}),
+	}
+	verifyCmd.Flags().String(addressFlag, "", "expected signer address (bech32, short id or 0x evm address)")
+
+	for _, cmd := range []*cobra.Command{signCmd, verifyCmd} {
+		cmd.Flags().String(hashModeFlag, string(signer.HashModeRaw), "message hash mode: raw, wallet (camino wallet prefixed) or eip191 (personal_sign)")
+		cmd.Flags().Bool(hexMsgFlag, false, "message is 0x hex encoded bytes")
+		cmd.Flags().Uint32(networkIDFlag, constants.CaminoID, "network id used to format P-Chain address")
+	}
+
+	signTypedCmd := &cobra.Command{
+		Use:   "sign-typed <typed-data.json>",
+		Short: "Sign eip-712 typed data (types, primaryType, domain, message)",
+		Args:  cobra.ExactArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			typedData, err := signer.ReadTypedData(args[0])
+			if err != nil {
+				app.logger.Error(err)
+				return err
+			}
+			key, err := app.keyFromFlag(cmd, keyFlag)
+			if err != nil {
+				return err
+			}
+			sig, err := signer.NewMsgSignerFromKey(key, app.logger).SignTypedData(typedData)
+			if err != nil {
+				return err
+			}
+			return app.print(sig)
+		}),
+	}
+	signTypedCmd.Flags().String(keyFlag, "", keyFlagUsage)
+	_ = signTypedCmd.MarkFlagRequired(keyFlag)
+
+	verifyTypedCmd := &cobra.Command{
+		Use:   "verify-typed <typed-data.json> <signature>",
+		Short: "Recover eip-712 typed data signer and optionally check it against expected address",
+		Args:  cobra.ExactArgs(2),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			typedData, err := signer.ReadTypedData(args[0])
+			if err != nil {
+				app.logger.Error(err)
+				return err
+			}
+			sig, err := signer.DecodeSignature(args[1])
+			if err != nil {
+				return err
+			}
+			expectedAddr, err := cmd.Flags().GetString(addressFlag)
+			if err != nil {
+				return err
+			}
+			networkID, err := cmd.Flags().GetUint32(networkIDFlag)
+			if err != nil {
+				return err
+			}
+
+			verifier := signer.NewMsgVerifier(app.logger)
+			if expectedAddr == "" {
+				recoveredSigner, err := verifier.RecoverTypedData(typedData, sig, networkID)
+				if err != nil {
+					return err
+				}
+				return app.print(recoveredSigner)
+			}
+
+			recoveredSigner, ok, err := verifier.VerifyTypedData(typedData, sig, expectedAddr, networkID)
+			if err != nil {
+				return err
+			}
+			if err := app.print(&struct {
+				*signer.RecoveredSigner
+				Valid bool `json:"valid"`
+			}{recoveredSigner, ok}); err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("%w: %s", errSignerMismatch, expectedAddr)
+			}
+			return nil
+		}),
+	}
+	verifyTypedCmd.Flags().String(addressFlag, "", "expected signer address (bech32, short id or 0x evm address)")
+	verifyTypedCmd.Flags().Uint32(networkIDFlag, constants.CaminoID, "network id used to format P-Chain address")
+
+	msgCmd.AddCommand(signCmd, verifyCmd, signTypedCmd, verifyTypedCmd)
+	return msgCmd
+}
+
+func msgArgs(cmd *cobra.Command, msgStr string) ([]byte, signer.HashMode, uint32, error) {
+	modeStr, err := cmd.Flags().GetString(hashModeFlag)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	isHex, err := cmd.Flags().GetBool(hexMsgFlag)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	networkID, err := cmd.Flags().GetUint32(networkIDFlag)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	msg := []byte(msgStr)
+	if isHex {
+		msg, err = hexutil.Decode(msgStr)
+		if err != nil {
+			return nil, "", 0, err
+		}
+	}
+	return msg, signer.HashMode(modeStr), networkID, nil
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+
+	"caminoclient/pkg/camino"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	ownersFlag    = "owners"
+	thresholdFlag = "threshold"
+	ownerKeyFlag  = "owner-key"
+	issueFlag     = "issue"
+)
+
+func msigCmd() *cobra.Command {
+	msigCmd := &cobra.Command{
+		Use:   "msig",
+		Short: "P-Chain multisig aliases",
+	}
+
+	showCmd := &cobra.Command{
+		Use:   "show <alias>",
+		Short: "Show alias definition (owners, threshold, nonce) and its nested aliases",
+		Args:  cobra.ExactArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			aliasID, err := camino.ParseAddress(args[0])
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			alias, err := client.GetMultisigAlias(ctx, aliasID)
+			if err != nil {
+				return err
+			}
+			return app.print(alias)
+		}),
+	}
+
+	updateCmd := &cobra.Command{
+		Use:   "update <alias>",
+		Short: "Replace owners and threshold of existing alias, signed by its current owners",
+		Args:  cobra.ExactArgs(1),
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			aliasID, err := camino.ParseAddress(args[0])
+			if err != nil {
+				return err
+			}
+			owners, err := cmd.Flags().GetStringSlice(ownersFlag)
+			if err != nil {
+				return err
+			}
+			threshold, err := cmd.Flags().GetUint32(thresholdFlag)
+			if err != nil {
+				return err
+			}
+			fundsKey, err := app.keyFromFlag(cmd, keyFlag)
+			if err != nil {
+				return err
+			}
+			ownerKeys, err := app.keysFromFlag(cmd, ownerKeyFlag)
+			if err != nil {
+				return err
+			}
+			issue, err := cmd.Flags().GetBool(issueFlag)
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			result, err := client.UpdateMsigAliasTx(ctx, aliasID, owners, threshold, fundsKey, ownerKeys)
+			if err != nil {
+				return err
+			}
+			if issue {
+				if err := app.confirmIssue(cmd, client, "P", result.Tx.Bytes()); err != nil {
+					return err
+				}
+				if err := client.IssuePTx(ctx, result.Tx.Bytes()); err != nil {
+					return err
+				}
+			}
+			return app.print(result)
+		}),
+	}
+	updateCmd.Flags().StringSlice(ownersFlag, nil, "new alias owner addresses")
+	updateCmd.Flags().Uint32(thresholdFlag, 1, "new alias threshold")
+	updateCmd.Flags().String(keyFlag, "", keyFlagUsage+" (pays fee)")
+	updateCmd.Flags().StringSlice(ownerKeyFlag, nil, "current alias owner keys, same formats as --"+keyFlag)
+	updateCmd.Flags().Bool(issueFlag, false, "issue tx after building it")
+	_ = updateCmd.MarkFlagRequired(ownersFlag)
+	_ = updateCmd.MarkFlagRequired(keyFlag)
+	_ = updateCmd.MarkFlagRequired(ownerKeyFlag)
+
+	msigCmd.AddCommand(showCmd, updateCmd)
+	return msigCmd
+}
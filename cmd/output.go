@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"caminoclient/internal/errkind"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+var errUnknownOutput = errkind.New(errkind.InvalidInput, "unknown output format")
+
+// print renders command result to stdout in configured output format,
+// logs are written to stderr, so stdout only contains results
+func (a *app) print(v interface{}) error {
+	return render(os.Stdout, a.cfg.Output, v)
+}
+
+func render(w io.Writer, output string, v interface{}) error {
+	switch output {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	case outputYAML, outputText:
+		// round-trip through json, so that json field names and marshalers are respected
+		jsonBytes, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+		decoder.UseNumber()
+		var generic interface{}
+		if err := decoder.Decode(&generic); err != nil {
+			return err
+		}
+		generic = normalizeNumbers(generic)
+		if output == outputText {
+			renderText(w, generic, 0)
+			return nil
+		}
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(generic); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	return fmt.Errorf("%w: %s", errUnknownOutput, output)
+}
+
+// renderText renders json-like value as indented "key: value" lines
+func renderText(w io.Writer, v interface{}, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			switch value := v[key].(type) {
+			case map[string]interface{}, []interface{}:
+				fmt.Fprintf(w, "%s%s:\n", indent, key)
+				renderText(w, value, depth+1)
+			default:
+				fmt.Fprintf(w, "%s%s: %v\n", indent, key, textValue(value))
+			}
+		}
+	case []interface{}:
+		for i, elem := range v {
+			switch elem.(type) {
+			case map[string]interface{}, []interface{}:
+				fmt.Fprintf(w, "%s[%d]:\n", indent, i)
+				renderText(w, elem, depth+1)
+			default:
+				fmt.Fprintf(w, "%s- %v\n", indent, textValue(elem))
+			}
+		}
+	default:
+		fmt.Fprintf(w, "%s%v\n", indent, textValue(v))
+	}
+}
+
+func textValue(v interface{}) interface{} {
+	if v == nil {
+		return "-"
+	}
+	return v
+}
+
+// normalizeNumbers converts json numbers into integers where possible,
+// so that large amounts are not rendered in float notation
+func normalizeNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = normalizeNumbers(value)
+		}
+	case []interface{}:
+		for i, elem := range v {
+			v[i] = normalizeNumbers(elem)
+		}
+	case json.Number:
+		if n, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return n
+		}
+		if n, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil && strings.ContainsAny(v.String(), ".eE") {
+			return f
+		}
+		// integer exceeding 64 bits
+		return v.String()
+	}
+	return v
+}
@@ -2,62 +2,60 @@ package cmd
 
 import (
 	"context"
-	"log"
 	"os/signal"
 	"syscall"
 
 	"caminoclient/internal/config"
-	"caminoclient/internal/logger"
+	"caminoclient/internal/errkind"
 	"caminoclient/internal/playground"
 
 	"github.com/spf13/cobra"
-	"go.uber.org/zap"
 )
 
-func Execute() error {
+// Execute runs command and returns its exit code, errors are written to stderr
+func Execute() int {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	rootCmd := &cobra.Command{
 		Use: "playground",
-		Run: func(cmd *cobra.Command, args []string) {
-			zapLogger, err := zap.NewDevelopment()
-			if err != nil {
-				log.Fatal(err)
-			}
-			zapSugaredLogger := zapLogger.Sugar()
-			defer func() { _ = zapSugaredLogger.Sync() }()
-
-			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
-			defer stop()
-
-			cfg, err := config.ReadConfig(ctx, zapSugaredLogger)
-			if err != nil {
-				return
-			}
-
-			if cfg.LogLevel == "info" {
-				zapLogger, err = zap.NewProduction()
-				if err != nil {
-					log.Fatal(err)
-				}
-				_ = zapSugaredLogger.Sync()
-				zapSugaredLogger = zapLogger.Sugar()
-				defer func() { _ = zapSugaredLogger.Sync() }()
-			}
-
-			playground, err := playground.NewPlayground(
-				ctx,
-				logger.NewLoggerFromZap(zapSugaredLogger),
-				cfg,
-			)
+		// errors are written by writeError with exit code
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			playground, err := playground.NewPlayground(ctx, app.logger, app.cfg)
 			if err != nil {
-				playground.Close(context.Background())
-				return
+				return err
 			}
-
-			playground.Run(ctx)
-		},
+			defer playground.Close(context.Background())
+			return playground.Run(ctx)
+		}),
 	}
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return errkind.Wrap(errkind.InvalidInput, err)
+	})
 	if err := config.BindFlags(rootCmd); err != nil {
-		return err
+		return writeError(err)
+	}
+	rootCmd.PersistentFlags().Bool(yesFlag, false, "issue on production network without confirmation prompt")
+	rootCmd.AddCommand(
+		erc20Cmd(),
+		contractCmd(),
+		logsCmd(),
+		msgCmd(),
+		addrCmd(),
+		hdCmd(),
+		msigCmd(),
+		batchCmd(),
+		transferCmd(),
+		exportCmd(),
+		dacCmd(),
+		partialCmd(),
+		serveCmd(),
+		auditCmd(),
+	)
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		return writeError(err)
 	}
-	return rootCmd.Execute()
+	return exitOK
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/server"
+	"caminoclient/pkg/camino"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	apiTokensEnv = "CAMINO_API_TOKENS"
+
+	listenAddrFlag      = "addr"
+	keystoreFlag        = "keystore"
+	shutdownTimeoutFlag = "shutdown-timeout"
+)
+
+var errNoAPITokens = errkind.New(errkind.InvalidInput, apiTokensEnv+" env var must be set to comma-separated api tokens")
+
+func serveCmd() *cobra.Command {
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve tx builders, decoder, issuer and message verifier as json http api",
+		Long: `Serve tx builders, decoder, issuer and message verifier as json http api.
+Requests must have "Authorization: Bearer <token>" header with one of tokens from ` + apiTokensEnv + ` env var.
+Requests reference signer keys by name from --keystore json file, which maps names to keys
+in --key formats, e.g. {"treasury": "PrivateKey-...", "ops": "hd:1"}.
+OpenAPI spec is served at /v1/openapi.json.`,
+		Args: cobra.NoArgs,
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			addr, err := cmd.Flags().GetString(listenAddrFlag)
+			if err != nil {
+				return err
+			}
+			keystorePath, err := cmd.Flags().GetString(keystoreFlag)
+			if err != nil {
+				return err
+			}
+			shutdownTimeout, err := cmd.Flags().GetDuration(shutdownTimeoutFlag)
+			if err != nil {
+				return err
+			}
+			tokens := apiTokensFromEnv()
+			if len(tokens) == 0 {
+				return errNoAPITokens
+			}
+			keystore, err := app.readKeystore(keystorePath)
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			apiServer, err := server.New(client, keystore, app.logger, server.Config{
+				Addr:            addr,
+				Tokens:          tokens,
+				ShutdownTimeout: shutdownTimeout,
+			})
+			if err != nil {
+				return err
+			}
+			return apiServer.Run(ctx)
+		}),
+	}
+	serveCmd.Flags().String(listenAddrFlag, "127.0.0.1:8080", "listen address")
+	serveCmd.Flags().String(keystoreFlag, "", "json file with named keys")
+	serveCmd.Flags().Duration(shutdownTimeoutFlag, 30*time.Second, "how long in-flight requests are waited for on shutdown")
+	_ = serveCmd.MarkFlagRequired(keystoreFlag)
+	return serveCmd
+}
+
+// readKeystore reads json file, mapping key names to keys in keyFlagUsage formats
+func (a *app) readKeystore(path string) (*server.Keystore, error) {
+	keystoreBytes, err := os.ReadFile(path)
+	if err != nil {
+		a.logger.Error(err)
+		return nil, err
+	}
+	keyStrs := map[string]string{}
+	if err := json.Unmarshal(keystoreBytes, &keyStrs); err != nil {
+		a.logger.Error(err)
+		return nil, err
+	}
+	keys := make(map[string]*camino.PrivateKey, len(keyStrs))
+	for name, keyStr := range keyStrs {
+		if keys[name], err = a.parseKey(keyStr); err != nil {
+			return nil, err
+		}
+	}
+	return server.NewKeystore(keys), nil
+}
+
+func apiTokensFromEnv() []string {
+	var tokens []string
+	for _, token := range strings.Split(os.Getenv(apiTokensEnv), ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"caminoclient/internal/crosschain"
+	"caminoclient/internal/errkind"
+	"caminoclient/pkg/camino"
+
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/spf13/cobra"
+)
+
+const (
+	memoFlag   = "memo"
+	fromFlag   = "from"
+	toFlag     = "to"
+	amountFlag = "amount"
+	stateFlag  = "state"
+	targetFlag = "target"
+
+	// P-Chain avax amounts are in nCAM
+	pChainDecimals = 9
+)
+
+var (
+	errInvalidRecipient = errkind.New(errkind.InvalidInput, "invalid recipient, expected <address>[,<address>...][:<threshold>[:<locktime>]]")
+	errTransferDone     = errkind.New(errkind.InvalidInput, "transfer from state file is already done, remove it or use another --"+stateFlag)
+	errCrossChainArgs   = errkind.New(errkind.InvalidInput, "cross-chain transfer requires --"+fromFlag+", --"+toFlag+" and --"+amountFlag+" and no positional args")
+	errRecipientPairs   = errkind.New(errkind.InvalidInput, "expected one or more <to> <amount> pairs")
+)
+
+func transferCmd() *cobra.Command {
+	transferCmd := &cobra.Command{
+		Use:   "transfer <to> <amount> [<to> <amount>...] | --from <chain> --to <chain> --amount <amount>",
+		Short: "Send CAM on P-Chain or move it between P-Chain and C-Chain",
+		Long: `Send CAM on P-Chain from unlocked funds of --key.
+Recipient is <address>[,<address>...][:<threshold>[:<locktime>]]: several addresses
+make multisig output spendable by threshold (default 1) of them after locktime (unix seconds).
+Amount is decimal CAM, e.g. 1.5.
+
+With --from and --to (C and P, either direction) moves --amount of --key funds between chains:
+exports it, waits for export acceptance, imports it to --key address and waits for import acceptance.
+Import fee is deducted from imported amount. Progress is saved to --state file after every step,
+interrupted transfer is resumed from it by running the same command again.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed(fromFlag) || cmd.Flags().Changed(toFlag) {
+				if len(args) != 0 || !cmd.Flags().Changed(fromFlag) ||
+					!cmd.Flags().Changed(toFlag) || !cmd.Flags().Changed(amountFlag) {
+					return errCrossChainArgs
+				}
+				return nil
+			}
+			if len(args) < 2 || len(args)%2 != 0 {
+				return errRecipientPairs
+			}
+			return nil
+		},
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed(fromFlag) {
+				return runCrossChainTransfer(ctx, app, cmd)
+			}
+			recipients, err := parseTransferRecipients(args)
+			if err != nil {
+				return err
+			}
+			memo, err := cmd.Flags().GetString(memoFlag)
+			if err != nil {
+				return err
+			}
+			fundsKey, err := app.keyFromFlag(cmd, keyFlag)
+			if err != nil {
+				return err
+			}
+			issue, err := cmd.Flags().GetBool(issueFlag)
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			result, err := client.TransferTx(ctx, recipients, []byte(memo), fundsKey)
+			if err != nil {
+				return err
+			}
+			if issue {
+				if err := app.confirmIssue(cmd, client, "P", result.Tx.Bytes()); err != nil {
+					return err
+				}
+				if err := client.IssuePTx(ctx, result.Tx.Bytes()); err != nil {
+					return err
+				}
+			}
+			return app.print(result)
+		}),
+	}
+	transferCmd.Flags().String(keyFlag, "", keyFlagUsage+" (sends funds and pays fee)")
+	transferCmd.Flags().String(memoFlag, "", "tx memo")
+	transferCmd.Flags().Bool(issueFlag, false, "issue tx after building it")
+	transferCmd.Flags().String(fromFlag, "", "source chain of cross-chain transfer (C or P)")
+	transferCmd.Flags().String(toFlag, "", "destination chain of cross-chain transfer (C or P)")
+	transferCmd.Flags().String(amountFlag, "", "decimal CAM amount of cross-chain transfer")
+	transferCmd.Flags().String(stateFlag, crosschain.DefaultStatePath, "cross-chain transfer state file")
+	_ = transferCmd.MarkFlagRequired(keyFlag)
+	return transferCmd
+}
+
+func exportCmd() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use:   "export <to> <amount> [<to> <amount>...]",
+		Short: "Export CAM from C-Chain to P-Chain outputs",
+		Long: `Export CAM from evm addresses of one or more --key to outputs on --target chain.
+Recipient is <address>[,<address>...][:<threshold>[:<locktime>]], same as in transfer command,
+so funds can be exported directly to multisig owners. Keys are spent in given order,
+each used key adds tx input. Exported funds must be imported on target chain.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 || len(args)%2 != 0 {
+				return errRecipientPairs
+			}
+			return nil
+		},
+		RunE: runWithApp(func(ctx context.Context, app *app, cmd *cobra.Command, args []string) error {
+			recipients, err := parseTransferRecipients(args)
+			if err != nil {
+				return err
+			}
+			fundsKeys, err := app.keysFromFlag(cmd, keyFlag)
+			if err != nil {
+				return err
+			}
+			targetChain, err := cmd.Flags().GetString(targetFlag)
+			if err != nil {
+				return err
+			}
+			issue, err := cmd.Flags().GetBool(issueFlag)
+			if err != nil {
+				return err
+			}
+			client, err := app.nodeClient(ctx)
+			if err != nil {
+				return err
+			}
+			result, err := client.EVMExportTx(ctx, recipients, fundsKeys, strings.ToUpper(targetChain))
+			if err != nil {
+				return err
+			}
+			if issue {
+				if err := app.confirmIssue(cmd, client, "C", result.Tx.Bytes()); err != nil {
+					return err
+				}
+				if err := client.IssueCTx(ctx, result.Tx.Bytes()); err != nil {
+					return err
+				}
+			}
+			return app.print(result)
+		}),
+	}
+	exportCmd.Flags().StringSlice(keyFlag, nil, keyFlagUsage+" (sends funds and pays fee), can be repeated")
+	exportCmd.Flags().String(targetFlag, "P", "target chain")
+	exportCmd.Flags().Bool(issueFlag, false, "issue tx after building it")
+	_ = exportCmd.MarkFlagRequired(keyFlag)
+	return exportCmd
+}
+
+// parseTransferRecipients parses <to> <amount> pairs
+func parseTransferRecipients(args []string) ([]*camino.TransferRecipient, error) {
+	recipients := make([]*camino.TransferRecipient, 0, len(args)/2)
+	for i := 0; i < len(args); i += 2 {
+		recipient, err := parseTransferRecipient(args[i])
+		if err != nil {
+			return nil, err
+		}
+		amount, err := camino.ParseAmount(args[i+1], pChainDecimals)
+		if err != nil {
+			return nil, err
+		}
+		if !amount.IsUint64() {
+			return nil, fmt.Errorf("amount %s is too large", args[i+1])
+		}
+		recipient.Amount = amount.Uint64()
+		recipients = append(recipients, recipient)
+	}
+	return recipients, nil
+}
+
+// runCrossChainTransfer starts transfer between chains or resumes it from state file
+func runCrossChainTransfer(ctx context.Context, app *app, cmd *cobra.Command) error {
+	from, err := cmd.Flags().GetString(fromFlag)
+	if err != nil {
+		return err
+	}
+	to, err := cmd.Flags().GetString(toFlag)
+	if err != nil {
+		return err
+	}
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if err := crosschain.ValidateChains(from, to); err != nil {
+		return err
+	}
+	amountStr, err := cmd.Flags().GetString(amountFlag)
+	if err != nil {
+		return err
+	}
+	amount, err := camino.ParseAmount(amountStr, pChainDecimals)
+	if err != nil {
+		return err
+	}
+	if !amount.IsUint64() {
+		return fmt.Errorf("amount %s is too large", amountStr)
+	}
+	statePath, err := cmd.Flags().GetString(stateFlag)
+	if err != nil {
+		return err
+	}
+	key, err := app.keyFromFlag(cmd, keyFlag)
+	if err != nil {
+		return err
+	}
+
+	client, err := app.nodeClient(ctx)
+	if err != nil {
+		return err
+	}
+	keyAddr, err := address.Format("P", client.HRP(), key.Address().Bytes())
+	if err != nil {
+		return err
+	}
+
+	state := crosschain.NewState(from, to, amount.Uint64(), keyAddr)
+	savedState, err := crosschain.LoadState(statePath)
+	if err != nil {
+		return err
+	}
+	if savedState != nil {
+		if err := savedState.Matches(state); err != nil {
+			return err
+		}
+		if savedState.Step == crosschain.StepDone {
+			return errTransferDone
+		}
+		app.logger.Infof("Resuming transfer from %s step", savedState.Step)
+		state = savedState
+	} else if err := state.Save(statePath); err != nil {
+		return err
+	}
+
+	if err := app.confirm(cmd, client, state); err != nil {
+		return err
+	}
+	if err := crosschain.Run(ctx, client, app.logger, key, state, statePath); err != nil {
+		return err
+	}
+	return app.print(state)
+}
+
+// parseTransferRecipient parses <address>[,<address>...][:<threshold>[:<locktime>]]
+func parseTransferRecipient(recipientStr string) (*camino.TransferRecipient, error) {
+	parts := strings.Split(recipientStr, ":")
+	if len(parts) > 3 || parts[0] == "" {
+		return nil, fmt.Errorf("%w: %q", errInvalidRecipient, recipientStr)
+	}
+	recipient := &camino.TransferRecipient{
+		Owners:    strings.Split(parts[0], ","),
+		Threshold: 1,
+	}
+	if len(parts) > 1 {
+		threshold, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", errInvalidRecipient, recipientStr, err)
+		}
+		recipient.Threshold = uint32(threshold)
+	}
+	if len(parts) > 2 {
+		locktime, err := strconv.ParseUint(parts[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", errInvalidRecipient, recipientStr, err)
+		}
+		recipient.Locktime = locktime
+	}
+	return recipient, nil
+}
@@ -0,0 +1,334 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// Events, that are recorded
+const (
+	EventSigned  = "signed"
+	EventIssuing = "issuing" // recorded before tx is submitted to node
+	EventIssued  = "issued"
+)
+
+const (
+	// maxEntrySize limits size of single log line, entries carry full tx bytes
+	maxEntrySize = 4 << 20
+	// tailChunkSize is size of chunks, in which log is read from its end to find last entry
+	tailChunkSize = 4096
+)
+
+// TornSuffix is appended to log path to get file, where Repair moves torn entry
+const TornSuffix = ".torn"
+
+var (
+	ErrBrokenChain = errors.New("audit log hash chain is broken")
+	// ErrTornEntry is returned, if last log line isn't complete, because its write was interrupted.
+	// Log can't be appended, until torn entry is removed with Repair.
+	ErrTornEntry   = errors.New("audit log ends with torn entry")
+	errEntryTooBig = errors.New("audit log entry is too big")
+)
+
+// Entry is audit log record of signed or issued tx.
+// Hash is sha256 of entry json with empty Hash, so it covers PrevHash and chains entries.
+type Entry struct {
+	Seq       uint64    `json:"seq"`
+	Time      time.Time `json:"time"`
+	Event     string    `json:"event"`
+	NetworkID uint32    `json:"networkID"`
+	Chain     string    `json:"chain"`
+	TxType    string    `json:"txType"`
+	TxID      ids.ID    `json:"txID"`
+	Signers   []string  `json:"signers"`
+	// Amount is nCAM, that tx sends to addresses other than its signers
+	Amount   uint64 `json:"amount"`
+	Operator string `json:"operator"`
+	Tx       string `json:"tx"` // hex
+	PrevHash string `json:"prevHash"`
+	Hash     string `json:"hash"`
+}
+
+func (e *Entry) computeHash() (string, error) {
+	unhashed := *e
+	unhashed.Hash = ""
+	entryBytes, err := json.Marshal(&unhashed)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(entryBytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Log is append-only hash-chained audit log file with json entry per line.
+// Log file can be shared by processes, appends are serialized with exclusive file lock.
+type Log struct {
+	path     string
+	operator string
+	lock     sync.Mutex
+}
+
+// Open creates log file, if it doesn't exist, and returns log, which entries are recorded with operator
+func Open(path, operator string) (*Log, error) {
+	logFile, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := logFile.Close(); err != nil {
+		return nil, err
+	}
+	return &Log{path: path, operator: operator}, nil
+}
+
+// Append chains entry to last log entry and writes it.
+// Entry seq, time, operator and hashes are set by log.
+func (l *Log) Append(entry *Entry) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	logFile, err := os.OpenFile(l.path, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	// other processes, e.g. server and cli, could append to the same log
+	if err := lockFile(logFile); err != nil {
+		return err
+	}
+	defer unlockFile(logFile)
+
+	torn, err := tornSize(logFile)
+	if err != nil {
+		return err
+	}
+	if torn > 0 {
+		return tornError(l.path, torn)
+	}
+	last, err := lastEntry(logFile)
+	if err != nil {
+		return err
+	}
+	entry.Seq = 1
+	entry.PrevHash = ""
+	if last != nil {
+		entry.Seq = last.Seq + 1
+		entry.PrevHash = last.Hash
+	}
+	entry.Time = time.Now().UTC()
+	entry.Operator = l.operator
+	if entry.Hash, err = entry.computeHash(); err != nil {
+		return err
+	}
+
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := logFile.Write(append(entryBytes, '\n')); err != nil {
+		return err
+	}
+	return logFile.Sync()
+}
+
+// lastEntry reads log file backwards until it finds its last line
+func lastEntry(logFile *os.File) (*Entry, error) {
+	info, err := logFile.Stat()
+	if err != nil {
+		return nil, err
+	}
+	end := info.Size()
+	var tail []byte
+	for end > 0 {
+		start := end - tailChunkSize
+		if start < 0 {
+			start = 0
+		}
+		chunk := make([]byte, end-start)
+		if _, err := logFile.ReadAt(chunk, start); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		tail = append(chunk, tail...)
+		end = start
+		trimmed := bytes.TrimRight(tail, "\n")
+		if i := bytes.LastIndexByte(trimmed, '\n'); i >= 0 || end == 0 {
+			if len(trimmed) == 0 {
+				return nil, nil
+			}
+			entry := &Entry{}
+			if err := json.Unmarshal(trimmed[i+1:], entry); err != nil {
+				return nil, fmt.Errorf("%w: last entry: %v", ErrBrokenChain, err)
+			}
+			return entry, nil
+		}
+		if len(tail) > maxEntrySize {
+			return nil, errEntryTooBig
+		}
+	}
+	return nil, nil
+}
+
+// tornSize returns size of torn entry at the end of log file, 0 if log ends with complete line
+func tornSize(logFile *os.File) (int64, error) {
+	info, err := logFile.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := info.Size()
+	end := size
+	for end > 0 {
+		start := end - tailChunkSize
+		if start < 0 {
+			start = 0
+		}
+		chunk := make([]byte, end-start)
+		if _, err := logFile.ReadAt(chunk, start); err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+		if i := bytes.LastIndexByte(chunk, '\n'); i >= 0 {
+			return size - start - int64(i) - 1, nil
+		}
+		end = start
+		if size-end > maxEntrySize {
+			return 0, errEntryTooBig
+		}
+	}
+	return size, nil
+}
+
+func tornError(path string, torn int64) error {
+	return fmt.Errorf("%w: last %d bytes of %s, remove them with audit repair", ErrTornEntry, torn, path)
+}
+
+// Repair moves torn entry at the end of log to file with TornSuffix,
+// so log can be appended again. It returns size of moved entry.
+func Repair(path string) (int64, error) {
+	logFile, err := os.OpenFile(path, os.O_RDWR, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	defer logFile.Close()
+
+	if err := lockFile(logFile); err != nil {
+		return 0, err
+	}
+	defer unlockFile(logFile)
+
+	torn, err := tornSize(logFile)
+	if err != nil || torn == 0 {
+		return 0, err
+	}
+	info, err := logFile.Stat()
+	if err != nil {
+		return 0, err
+	}
+	tornBytes := make([]byte, torn)
+	if _, err := logFile.ReadAt(tornBytes, info.Size()-torn); err != nil {
+		return 0, err
+	}
+
+	tornFile, err := os.OpenFile(path+TornSuffix, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	defer tornFile.Close()
+	if _, err := tornFile.Write(append(tornBytes, '\n')); err != nil {
+		return 0, err
+	}
+	if err := tornFile.Sync(); err != nil {
+		return 0, err
+	}
+
+	if err := logFile.Truncate(info.Size() - torn); err != nil {
+		return 0, err
+	}
+	return torn, logFile.Sync()
+}
+
+// ReadEntries reads all log entries. If log ends with torn entry,
+// complete entries are returned with ErrTornEntry.
+func ReadEntries(path string) ([]*Entry, error) {
+	entries, torn, err := readEntries(path)
+	if err != nil {
+		return nil, err
+	}
+	if torn > 0 {
+		return entries, tornError(path, torn)
+	}
+	return entries, nil
+}
+
+// readEntries reads complete log entries and returns size of torn entry after them
+func readEntries(path string) ([]*Entry, int64, error) {
+	logFile, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer logFile.Close()
+
+	torn, err := tornSize(logFile)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := logFile.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := []*Entry{}
+	scanner := bufio.NewScanner(io.LimitReader(logFile, info.Size()-torn))
+	scanner.Buffer(make([]byte, 0, tailChunkSize), maxEntrySize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		entry := &Entry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, 0, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, torn, scanner.Err()
+}
+
+// Verify checks, that every entry hash matches its content and chains it to previous entry.
+// It returns number of verified entries. Torn entry at the end of log is reported
+// with ErrTornEntry after complete entries are verified.
+func Verify(path string) (int, error) {
+	entries, torn, err := readEntries(path)
+	if err != nil {
+		return 0, err
+	}
+	prevHash := ""
+	for i, entry := range entries {
+		if entry.Seq != uint64(i+1) {
+			return i, fmt.Errorf("%w: entry %d has seq %d", ErrBrokenChain, i+1, entry.Seq)
+		}
+		if entry.PrevHash != prevHash {
+			return i, fmt.Errorf("%w: entry %d prevHash doesn't match hash of entry %d", ErrBrokenChain, entry.Seq, i)
+		}
+		hash, err := entry.computeHash()
+		if err != nil {
+			return i, err
+		}
+		if entry.Hash != hash {
+			return i, fmt.Errorf("%w: entry %d hash doesn't match its content", ErrBrokenChain, entry.Seq)
+		}
+		prevHash = entry.Hash
+	}
+	if torn > 0 {
+		return len(entries), tornError(path, torn)
+	}
+	return len(entries), nil
+}
@@ -0,0 +1,204 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func newTestEntry(amount uint64) *Entry {
+	return &Entry{
+		Event:     EventSigned,
+		NetworkID: 1000,
+		Chain:     "P",
+		TxType:    "BaseTx",
+		TxID:      ids.ID{byte(amount)},
+		Signers:   []string{"P-kopernikus1g65uqn6t77p656w64023nh8nd9updzmxh8ttv3"},
+		Amount:    amount,
+		Tx:        "0x00",
+	}
+}
+
+func appendEntries(t *testing.T, log *Log, amounts ...uint64) {
+	t.Helper()
+	for _, amount := range amounts {
+		if err := log.Append(newTestEntry(amount)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func openTestLog(t *testing.T, path string) *Log {
+	t.Helper()
+	log, err := Open(path, "operator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return log
+}
+
+func TestLogChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	appendEntries(t, openTestLog(t, path), 1, 2)
+
+	// reopened log continues chain
+	appendEntries(t, openTestLog(t, path), 3)
+
+	entries, err := ReadEntries(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	prevHash := ""
+	for i, entry := range entries {
+		if entry.Seq != uint64(i+1) || entry.PrevHash != prevHash || entry.Operator != "operator" {
+			t.Errorf("entry %d: unexpected seq %d, prevHash %q or operator %q", i, entry.Seq, entry.PrevHash, entry.Operator)
+		}
+		prevHash = entry.Hash
+	}
+	if verified, err := Verify(path); err != nil || verified != 3 {
+		t.Errorf("expected 3 verified entries, got %d: %v", verified, err)
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	tests := []struct {
+		name     string
+		tamper   func(lines []string) []string
+		verified int
+		broken   bool
+	}{
+		{
+			name: "changed entry",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], `"amount":2`, `"amount":20`, 1)
+				return lines
+			},
+			verified: 1,
+			broken:   true,
+		},
+		{
+			name:     "removed entry",
+			tamper:   func(lines []string) []string { return append(lines[:1], lines[2:]...) },
+			verified: 1,
+			broken:   true,
+		},
+		{
+			name: "reordered entries",
+			tamper: func(lines []string) []string {
+				lines[1], lines[2] = lines[2], lines[1]
+				return lines
+			},
+			verified: 1,
+			broken:   true,
+		},
+		// removed tail can't be detected by chain itself
+		{
+			name:     "removed last entry",
+			tamper:   func(lines []string) []string { return lines[:2] },
+			verified: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.log")
+			appendEntries(t, openTestLog(t, path), 1, 2, 3)
+			logBytes, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := tt.tamper(strings.Split(strings.TrimSuffix(string(logBytes), "\n"), "\n"))
+			if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			verified, err := Verify(path)
+			if errors.Is(err, ErrBrokenChain) != tt.broken || verified != tt.verified {
+				t.Errorf("expected %d verified entries and broken %v, got %d: %v", tt.verified, tt.broken, verified, err)
+			}
+		})
+	}
+}
+
+func TestTornEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	log := openTestLog(t, path)
+	appendEntries(t, log, 1, 2)
+	logFile, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	torn := `{"seq":3,"time":"2023-`
+	if _, err := logFile.WriteString(torn); err != nil {
+		t.Fatal(err)
+	}
+	if err := logFile.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := log.Append(newTestEntry(3)); !errors.Is(err, ErrTornEntry) {
+		t.Fatalf("expected ErrTornEntry, got %v", err)
+	}
+	if verified, err := Verify(path); !errors.Is(err, ErrTornEntry) || verified != 2 {
+		t.Fatalf("expected ErrTornEntry after 2 entries, got %d: %v", verified, err)
+	}
+
+	repaired, err := Repair(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repaired != int64(len(torn)) {
+		t.Errorf("expected %d repaired bytes, got %d", len(torn), repaired)
+	}
+	tornBytes, err := os.ReadFile(path + TornSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tornBytes) != torn+"\n" {
+		t.Errorf("unexpected torn file content %q", tornBytes)
+	}
+
+	// repaired log is appended to last complete entry
+	appendEntries(t, log, 3)
+	if verified, err := Verify(path); err != nil || verified != 3 {
+		t.Errorf("expected 3 verified entries, got %d: %v", verified, err)
+	}
+	if repaired, err := Repair(path); err != nil || repaired != 0 {
+		t.Errorf("expected nothing to repair, got %d: %v", repaired, err)
+	}
+}
+
+func TestConcurrentAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	// every log is opened separately, as by different processes
+	const logs, entriesPerLog = 4, 10
+	wg := sync.WaitGroup{}
+	errs := make(chan error, logs*entriesPerLog)
+	for i := 0; i < logs; i++ {
+		log := openTestLog(t, path)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < entriesPerLog; j++ {
+				errs <- log.Append(newTestEntry(uint64(j)))
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if verified, err := Verify(path); err != nil || verified != logs*entriesPerLog {
+		t.Errorf("expected %d verified entries, got %d: %v", logs*entriesPerLog, verified, err)
+	}
+}
@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var csvColumns = []string{
+	"seq", "time", "event", "networkID", "chain", "txType", "txID",
+	"signers", "amount", "operator", "tx", "prevHash", "hash",
+}
+
+// WriteCSV writes entries as csv with header, signers are separated by spaces
+func WriteCSV(w io.Writer, entries []*Entry) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(csvColumns); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := csvWriter.Write([]string{
+			strconv.FormatUint(entry.Seq, 10),
+			entry.Time.Format(time.RFC3339Nano),
+			entry.Event,
+			strconv.FormatUint(uint64(entry.NetworkID), 10),
+			entry.Chain,
+			entry.TxType,
+			entry.TxID.String(),
+			strings.Join(entry.Signers, " "),
+			strconv.FormatUint(entry.Amount, 10),
+			entry.Operator,
+			entry.Tx,
+			entry.PrevHash,
+			entry.Hash,
+		}); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package audit
+
+import (
+	"math"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// windows locks are mandatory, so byte beyond file end is locked, that doesn't block reads
+var lockRange = windows.Overlapped{Offset: math.MaxUint32, OffsetHigh: math.MaxInt32}
+
+// lockFile takes exclusive lock of file, waiting until other processes release it
+func lockFile(file *os.File) error {
+	overlapped := lockRange
+	return windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, &overlapped)
+}
+
+func unlockFile(file *os.File) error {
+	overlapped := lockRange
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, &overlapped)
+}
@@ -0,0 +1,184 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/logger"
+	"caminoclient/internal/utils"
+	"caminoclient/pkg/camino"
+
+	"github.com/ava-labs/avalanchego/ids"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+)
+
+var errParentFailed = errors.New("tx, which change this tx spends, wasn't committed")
+
+// Config of batch run
+type Config struct {
+	FundsKey camino.Signer     // pays fees and transfers
+	Executor *camino.Authority // authorizes address state changes
+	// Concurrency is max number of txs issued at once
+	Concurrency int
+	// DryRun only builds txs without issuing them
+	DryRun bool
+}
+
+// job is built tx of row
+type job struct {
+	row     *Row
+	tx      *camino.PTxResult
+	parents []ids.ID
+}
+
+// Run builds txs for all rows that aren't committed yet and issues them.
+// Txs are built sequentially, each one spending change of previous ones in the same chain,
+// so building doesn't wait for acceptance. Rows are distributed between cfg.Concurrency
+// chains, which are issued concurrently; txs of one chain are issued one after another,
+// after their parents are committed. Rows are updated with tx id, status and error.
+// Rows with tx id from previous run are rebuilt only if node doesn't know their tx,
+// so re-run never issues the same row twice.
+func Run(ctx context.Context, client *camino.Client, logger logger.Logger, rows []*Row, cfg Config) error {
+	pool, err := client.NewPUTXOPool(ctx, cfg.FundsKey)
+	if err != nil {
+		return err
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	pools := pool.Split(cfg.Concurrency)
+
+	chains := make([][]*job, len(pools))
+	chainIndex := 0
+	for i, row := range rows {
+		if row.Status == StatusCommitted {
+			continue
+		}
+		if row.TxID != "" {
+			issued, err := checkIssuedRow(ctx, client, row)
+			if err != nil {
+				logger.Errorf("row %d: %v", i+1, err)
+				row.Error = err.Error()
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				continue
+			}
+			if issued {
+				continue
+			}
+		}
+		row.TxID, row.Status, row.Error = "", "", ""
+
+		chainPool := pools[chainIndex]
+		tx, err := buildRow(ctx, client, row, chainPool, cfg)
+		if err != nil {
+			logger.Errorf("row %d: %v", i+1, err)
+			row.Status, row.Error = StatusFailed, err.Error()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			continue
+		}
+		row.TxID, row.Status = tx.TxID.String(), StatusBuilt
+		chains[chainIndex] = append(chains[chainIndex], &job{
+			row:     row,
+			tx:      tx,
+			parents: chainPool.Parents(tx.Tx),
+		})
+		chainIndex = (chainIndex + 1) % len(pools)
+	}
+	if cfg.DryRun {
+		return nil
+	}
+
+	wg := sync.WaitGroup{}
+	for _, chain := range chains {
+		wg.Add(1)
+		go func(chain []*job) {
+			defer wg.Done()
+			issueChain(ctx, client, chain)
+		}(chain)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// checkIssuedRow checks status of row tx from previous run. If tx is committed or still processing,
+// row is updated and true is returned, so it isn't rebuilt. Otherwise, row tx wasn't issued or was rejected.
+func checkIssuedRow(ctx context.Context, client *camino.Client, row *Row) (bool, error) {
+	txID, err := ids.FromString(row.TxID)
+	if err != nil {
+		return false, err
+	}
+	txStatus, _, err := client.GetPTxStatus(ctx, txID)
+	if err != nil {
+		return false, err
+	}
+	switch txStatus {
+	case status.Committed:
+		row.Status, row.Error = StatusCommitted, ""
+		return true, nil
+	case status.Processing:
+		row.Status, row.Error = StatusIssued, ""
+		return true, nil
+	}
+	return false, nil
+}
+
+// issueChain issues txs one after another, waiting for each one to be committed.
+// Txs, which parents weren't committed, aren't issued.
+// Tx, which was issued, but wasn't committed while waiting, is marked as issued, not failed,
+// so its row isn't rebuilt by re-run before its status is known.
+func issueChain(ctx context.Context, client *camino.Client, chain []*job) {
+	notCommitted := map[ids.ID]struct{}{}
+	for _, job := range chain {
+		err := ctx.Err()
+		for _, parentID := range job.parents {
+			if _, ok := notCommitted[parentID]; ok {
+				err = fmt.Errorf("%w: %s", errParentFailed, parentID)
+				break
+			}
+		}
+		if err == nil {
+			err = client.IssuePTx(ctx, job.tx.Tx.Bytes())
+		}
+		if err != nil {
+			notCommitted[job.tx.TxID] = struct{}{}
+			job.row.Status, job.row.Error = StatusFailed, err.Error()
+			continue
+		}
+		job.row.Status = StatusIssued
+		if err := client.WaitForPTx(ctx, job.tx.TxID); err != nil {
+			notCommitted[job.tx.TxID] = struct{}{}
+			job.row.Error = err.Error()
+			if errkind.Of(err) == errkind.TxRejected {
+				job.row.Status = StatusFailed
+			}
+			continue
+		}
+		job.row.Status = StatusCommitted
+	}
+}
+
+func buildRow(ctx context.Context, client *camino.Client, row *Row, pool *camino.PUTXOPool, cfg Config) (*camino.PTxResult, error) {
+	switch row.Type {
+	case RowTypeAddressState:
+		addr, err := utils.ParseAddress(row.Address)
+		if err != nil {
+			return nil, err
+		}
+		return client.AddressStateTx(ctx, addr, as.AddressStateBit(row.State), row.Remove, cfg.FundsKey, cfg.Executor, camino.WithUTXOPool(pool))
+	case RowTypeTransfer:
+		return client.TransferTx(ctx, []*camino.TransferRecipient{{
+			Amount:    row.Amount,
+			Owners:    []string{row.Address},
+			Threshold: 1,
+		}}, nil, cfg.FundsKey, camino.WithUTXOPool(pool))
+	}
+	return nil, fmt.Errorf("%w: %q", errUnknownRowType, row.Type)
+}
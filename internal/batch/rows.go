@@ -0,0 +1,177 @@
+package batch
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"caminoclient/internal/errkind"
+)
+
+const (
+	RowTypeAddressState = "addressState"
+	RowTypeTransfer     = "transfer"
+
+	StatusBuilt     = "built"
+	StatusIssued    = "issued" // issued, but not known to be committed yet
+	StatusCommitted = "committed"
+	StatusFailed    = "failed"
+)
+
+var (
+	errUnknownFormat  = errkind.New(errkind.InvalidInput, "unknown batch file format, expected .csv or .json")
+	errMissingColumn  = errkind.New(errkind.InvalidInput, "missing csv column")
+	errUnknownRowType = errkind.New(errkind.InvalidInput, "unknown row type")
+)
+
+// csv columns, in order they are written
+var columns = []string{"type", "address", "state", "remove", "amount", "txID", "status", "error"}
+
+// Row is single batch operation and its result
+type Row struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+	State   uint8  `json:"state,omitempty"`
+	Remove  bool   `json:"remove,omitempty"`
+	Amount  uint64 `json:"amount,omitempty"` // nCAM
+
+	TxID   string `json:"txID,omitempty"`
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ReadRows reads rows from .csv (with header) or .json (array of rows) file.
+// Results file written by WriteRows can be read back, so failed rows can be re-run.
+func ReadRows(path string) ([]*Row, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		rows := []*Row{}
+		if err := json.NewDecoder(file).Decode(&rows); err != nil {
+			return nil, err
+		}
+		return rows, nil
+	case ".csv":
+		return readCSVRows(file)
+	}
+	return nil, errUnknownFormat
+}
+
+// WriteRows writes rows to .csv or .json file
+func WriteRows(path string, rows []*Row) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".json" && ext != ".csv" {
+		return errUnknownFormat
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if ext == ".json" {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	}
+	return writeCSVRows(file, rows)
+}
+
+// ResultsPath returns default results file path for input file: rows.csv -> rows.results.csv
+func ResultsPath(inputPath string) string {
+	ext := filepath.Ext(inputPath)
+	return strings.TrimSuffix(inputPath, ext) + ".results" + ext
+}
+
+func readCSVRows(r io.Reader) ([]*Row, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := map[string]int{}
+	for i, column := range records[0] {
+		header[strings.TrimSpace(column)] = i
+	}
+	for _, column := range []string{"type", "address"} {
+		if _, ok := header[column]; !ok {
+			return nil, fmt.Errorf("%w: %s", errMissingColumn, column)
+		}
+	}
+
+	rows := make([]*Row, 0, len(records)-1)
+	for i, record := range records[1:] {
+		value := func(column string) string {
+			index, ok := header[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+		row := &Row{
+			Type:    value("type"),
+			Address: value("address"),
+			TxID:    value("txID"),
+			Status:  value("status"),
+			Error:   value("error"),
+		}
+		if stateStr := value("state"); stateStr != "" {
+			state, err := strconv.ParseUint(stateStr, 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: state: %w", i+1, err)
+			}
+			row.State = uint8(state)
+		}
+		if amountStr := value("amount"); amountStr != "" {
+			row.Amount, err = strconv.ParseUint(amountStr, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: amount: %w", i+1, err)
+			}
+		}
+		if removeStr := value("remove"); removeStr != "" {
+			row.Remove, err = strconv.ParseBool(removeStr)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: remove: %w", i+1, err)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func writeCSVRows(w io.Writer, rows []*Row) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := csvWriter.Write([]string{
+			row.Type,
+			row.Address,
+			strconv.FormatUint(uint64(row.State), 10),
+			strconv.FormatBool(row.Remove),
+			strconv.FormatUint(row.Amount, 10),
+			row.TxID,
+			row.Status,
+			row.Error,
+		}); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"time"
 
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/spf13/cobra"
@@ -14,25 +15,104 @@ const (
 
 	configFlagKey = "config"
 
-	logLevelKey = "log_level"
+	logLevelKey         = "log_level"
+	logFormatKey        = "log_format"
+	logFileKey          = "log_file"
+	logMaxSizeKey       = "log_max_size"
+	logMaxBackupsKey    = "log_max_backups"
+	logMaxAgeKey        = "log_max_age"
+	nodeURIKey          = "node_uri"
+	nodeFallbackURIsKey = "node_fallback_uris"
+	rpcTimeoutKey       = "rpc_timeout"
+	rpcRetriesKey       = "rpc_retries"
+	rpcRetryBackoffKey  = "rpc_retry_backoff"
+	rpcRateLimitKey     = "rpc_rate_limit"
+
+	deploymentsDirKey = "deployments_dir"
+
+	signingPolicyKey = "signing_policy"
+	auditLogKey      = "audit_log"
+	auditOperatorKey = "audit_operator"
+
+	outputKey = "output"
 )
 
 func BindFlags(cmd *cobra.Command) error {
 	cmd.PersistentFlags().String(configFlagKey, ".", "path to config file dir")
 
-	cmd.PersistentFlags().String(logLevelKey, ".", "log_level")
+	cmd.PersistentFlags().String(logLevelKey, "info", "log level: debug, info, warn or error")
+	cmd.PersistentFlags().String(logFormatKey, "console", "log format: console or json")
+	cmd.PersistentFlags().String(logFileKey, "", "path to log file, that is rotated by size, logs are written to stderr if empty")
+	cmd.PersistentFlags().Int(logMaxSizeKey, 100, "size in megabytes, that log file is rotated at")
+	cmd.PersistentFlags().Int(logMaxBackupsKey, 5, "number of rotated log files to keep, 0 to keep all")
+	cmd.PersistentFlags().Int(logMaxAgeKey, 30, "days to keep rotated log files, 0 to keep forever")
+	cmd.PersistentFlags().String(nodeURIKey, "http://127.0.0.1:19651", "node uri")
+	cmd.PersistentFlags().StringSlice(nodeFallbackURIsKey, nil, "uris of nodes of the same network, used if node_uri fails")
+	cmd.PersistentFlags().Duration(rpcTimeoutKey, 30*time.Second, "timeout of single node rpc call, 0 to disable")
+	cmd.PersistentFlags().Int(rpcRetriesKey, 3, "max retries of failed node rpc call")
+	cmd.PersistentFlags().Duration(rpcRetryBackoffKey, 500*time.Millisecond, "delay before first retry of failed node rpc call, doubled for each next one")
+	cmd.PersistentFlags().Float64(rpcRateLimitKey, 0, "max node rpc calls per second, 0 to disable")
+
+	cmd.PersistentFlags().String(deploymentsDirKey, "deployments", "path to contract deployments registry dir")
+
+	cmd.PersistentFlags().String(signingPolicyKey, "", "path to signing policy json file, that every tx signing must satisfy")
+	cmd.PersistentFlags().String(auditLogKey, "", "path to audit log file, that records every signed and issued tx")
+	cmd.PersistentFlags().String(auditOperatorKey, "", "operator recorded in audit log, defaults to os user name")
+
+	cmd.PersistentFlags().String(outputKey, "json", "output format of command results: text, json or yaml")
 
 	errs := wrappers.Errs{}
 	errs.Add(
 		viper.BindPFlag(configFlagKey, cmd.PersistentFlags().Lookup(configFlagKey)),
 
 		viper.BindPFlag(logLevelKey, cmd.PersistentFlags().Lookup(logLevelKey)),
+		viper.BindPFlag(logFormatKey, cmd.PersistentFlags().Lookup(logFormatKey)),
+		viper.BindPFlag(logFileKey, cmd.PersistentFlags().Lookup(logFileKey)),
+		viper.BindPFlag(logMaxSizeKey, cmd.PersistentFlags().Lookup(logMaxSizeKey)),
+		viper.BindPFlag(logMaxBackupsKey, cmd.PersistentFlags().Lookup(logMaxBackupsKey)),
+		viper.BindPFlag(logMaxAgeKey, cmd.PersistentFlags().Lookup(logMaxAgeKey)),
+		viper.BindPFlag(nodeURIKey, cmd.PersistentFlags().Lookup(nodeURIKey)),
+		viper.BindPFlag(nodeFallbackURIsKey, cmd.PersistentFlags().Lookup(nodeFallbackURIsKey)),
+		viper.BindPFlag(rpcTimeoutKey, cmd.PersistentFlags().Lookup(rpcTimeoutKey)),
+		viper.BindPFlag(rpcRetriesKey, cmd.PersistentFlags().Lookup(rpcRetriesKey)),
+		viper.BindPFlag(rpcRetryBackoffKey, cmd.PersistentFlags().Lookup(rpcRetryBackoffKey)),
+		viper.BindPFlag(rpcRateLimitKey, cmd.PersistentFlags().Lookup(rpcRateLimitKey)),
+
+		viper.BindPFlag(deploymentsDirKey, cmd.PersistentFlags().Lookup(deploymentsDirKey)),
+
+		viper.BindPFlag(signingPolicyKey, cmd.PersistentFlags().Lookup(signingPolicyKey)),
+		viper.BindPFlag(auditLogKey, cmd.PersistentFlags().Lookup(auditLogKey)),
+		viper.BindPFlag(auditOperatorKey, cmd.PersistentFlags().Lookup(auditOperatorKey)),
+
+		viper.BindPFlag(outputKey, cmd.PersistentFlags().Lookup(outputKey)),
 	)
 	return errs.Err
 }
 
 type Config struct {
-	LogLevel string `mapstructure:"log_level"`
+	LogLevel      string `mapstructure:"log_level"`
+	LogFormat     string `mapstructure:"log_format"`
+	LogFile       string `mapstructure:"log_file"`
+	LogMaxSize    int    `mapstructure:"log_max_size"`
+	LogMaxBackups int    `mapstructure:"log_max_backups"`
+	LogMaxAge     int    `mapstructure:"log_max_age"`
+
+	NodeURI string `mapstructure:"node_uri"`
+
+	NodeFallbackURIs []string `mapstructure:"node_fallback_uris"`
+
+	RPCTimeout      time.Duration `mapstructure:"rpc_timeout"`
+	RPCRetries      int           `mapstructure:"rpc_retries"`
+	RPCRetryBackoff time.Duration `mapstructure:"rpc_retry_backoff"`
+	RPCRateLimit    float64       `mapstructure:"rpc_rate_limit"`
+
+	DeploymentsDir string `mapstructure:"deployments_dir"`
+
+	SigningPolicy string `mapstructure:"signing_policy"`
+	AuditLog      string `mapstructure:"audit_log"`
+	AuditOperator string `mapstructure:"audit_operator"`
+
+	Output string `mapstructure:"output"`
 }
 
 func ReadConfig(ctx context.Context, logger *zap.SugaredLogger) (*Config, error) {
@@ -53,3 +133,9 @@ func ReadConfig(ctx context.Context, logger *zap.SugaredLogger) (*Config, error)
 
 	return cfg, nil
 }
+
+// OutputFormat returns configured output format. It's available before command reads config,
+// so that command errors can be rendered even if config couldn't be read.
+func OutputFormat() string {
+	return viper.GetString(outputKey)
+}
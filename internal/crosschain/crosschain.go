@@ -0,0 +1,170 @@
+package crosschain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/logger"
+	"caminoclient/pkg/camino"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/coreth/plugin/evm"
+)
+
+var (
+	errUnsupportedChains = errkind.New(errkind.InvalidInput, "unsupported transfer chains, expected C -> P or P -> C")
+	errUnknownStep       = errors.New("unknown transfer step")
+)
+
+// ValidateChains returns error if funds can't be transferred from one chain to another
+func ValidateChains(from, to string) error {
+	if (from == "C" && to == "P") || (from == "P" && to == "C") {
+		return nil
+	}
+	return fmt.Errorf("%w: %s -> %s", errUnsupportedChains, from, to)
+}
+
+// Run executes remaining steps of transfer: export from source chain, wait for its acceptance,
+// import exported outputs to key address on destination chain and wait for import acceptance.
+// State is saved to statePath after every step. Built txs are saved before issuing them,
+// so resumed transfer issues the same txs instead of building new ones, unless node already knows them.
+func Run(ctx context.Context, client *camino.Client, logger logger.Logger, key camino.Signer, state *State, statePath string) error {
+	if err := ValidateChains(state.From, state.To); err != nil {
+		return err
+	}
+	for state.Step != StepDone {
+		step := state.Step
+		if err := runStep(ctx, client, key, state); err != nil {
+			return fmt.Errorf("%s step: %w", step, err)
+		}
+		if err := state.Save(statePath); err != nil {
+			return err
+		}
+		logger.Infof("transfer step %s done", step)
+	}
+	return nil
+}
+
+// runStep executes current step and moves state to the next one
+func runStep(ctx context.Context, client *camino.Client, key camino.Signer, state *State) error {
+	switch state.Step {
+	case StepExport:
+		var result *camino.TxResult
+		if state.From == "C" {
+			tx, err := client.EVMTx(ctx, state.Amount, key.Address(), key, state.To)
+			if err != nil {
+				return err
+			}
+			result = &tx.TxResult
+		} else {
+			tx, err := client.PExportTx(ctx, state.Amount, key.Address(), key, state.To)
+			if err != nil {
+				return err
+			}
+			result = &tx.TxResult
+		}
+		state.ExportTxID, state.ExportTx = result.TxID.String(), result.TxHex
+		state.Step = StepIssueExport
+
+	case StepIssueExport:
+		if err := issue(ctx, client, state.From, state.ExportTxID, state.ExportTx); err != nil {
+			return err
+		}
+		state.Step = StepWaitExport
+
+	case StepWaitExport:
+		if err := wait(ctx, client, state.From, state.ExportTxID); err != nil {
+			return err
+		}
+		state.Step = StepImport
+
+	case StepImport:
+		exportTxID, err := ids.FromString(state.ExportTxID)
+		if err != nil {
+			return err
+		}
+		var result *camino.TxResult
+		if state.To == "P" {
+			tx, err := client.PImportTx(ctx, state.From, exportTxID, key)
+			if err != nil {
+				return err
+			}
+			result = &tx.TxResult
+		} else {
+			tx, err := client.CImportTx(ctx, state.From, exportTxID, key, camino.EVMAddress(key))
+			if err != nil {
+				return err
+			}
+			result = &tx.TxResult
+		}
+		state.ImportTxID, state.ImportTx = result.TxID.String(), result.TxHex
+		state.ImportFee, state.Imported = result.Fee, 0
+		for _, out := range result.Produced {
+			state.Imported += out.Amount
+		}
+		state.Step = StepIssueImport
+
+	case StepIssueImport:
+		if err := issue(ctx, client, state.To, state.ImportTxID, state.ImportTx); err != nil {
+			return err
+		}
+		state.Step = StepWaitImport
+
+	case StepWaitImport:
+		if err := wait(ctx, client, state.To, state.ImportTxID); err != nil {
+			return err
+		}
+		state.Step = StepDone
+
+	default:
+		return fmt.Errorf("%w: %q", errUnknownStep, state.Step)
+	}
+	return nil
+}
+
+// issue issues saved tx on chain, unless node already knows it. Tx could be issued by
+// previous run, that was interrupted before state was saved; issuing it again would be
+// rejected by node, because its inputs are already consumed.
+func issue(ctx context.Context, client *camino.Client, chain, txIDStr, txHex string) error {
+	txID, err := ids.FromString(txIDStr)
+	if err != nil {
+		return err
+	}
+	txBytes, err := formatting.Decode(formatting.Hex, txHex)
+	if err != nil {
+		return err
+	}
+	if chain == "C" {
+		txStatus, err := client.GetCTxStatus(ctx, txID)
+		if err != nil {
+			return err
+		}
+		if txStatus == evm.Accepted || txStatus == evm.Processing {
+			return nil
+		}
+		return client.IssueCTx(ctx, txBytes)
+	}
+	txStatus, _, err := client.GetPTxStatus(ctx, txID)
+	if err != nil {
+		return err
+	}
+	if txStatus == status.Committed || txStatus == status.Processing {
+		return nil
+	}
+	return client.IssuePTx(ctx, txBytes)
+}
+
+func wait(ctx context.Context, client *camino.Client, chain, txIDStr string) error {
+	txID, err := ids.FromString(txIDStr)
+	if err != nil {
+		return err
+	}
+	if chain == "C" {
+		return client.WaitForCTx(ctx, txID)
+	}
+	return client.WaitForPTx(ctx, txID)
+}
@@ -0,0 +1,91 @@
+package crosschain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/utils"
+)
+
+// Step is next step of cross-chain transfer
+type Step string
+
+const (
+	StepExport      Step = "export"
+	StepIssueExport Step = "issueExport"
+	StepWaitExport  Step = "waitExport"
+	StepImport      Step = "import"
+	StepIssueImport Step = "issueImport"
+	StepWaitImport  Step = "waitImport"
+	StepDone        Step = "done"
+)
+
+// DefaultStatePath is state file used, if other isn't given
+const DefaultStatePath = "transfer.state.json"
+
+var errStateMismatch = errkind.New(errkind.InvalidInput, "state file belongs to another transfer")
+
+// State is progress of cross-chain transfer, persisted after every step,
+// so interrupted transfer can be resumed without exporting funds twice
+type State struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Amount  uint64 `json:"amount"`  // nCAM
+	Address string `json:"address"` // P-Chain address of key
+	Step    Step   `json:"step"`
+
+	ExportTxID string `json:"exportTxID,omitempty"`
+	ExportTx   string `json:"exportTx,omitempty"` // hex
+	ImportTxID string `json:"importTxID,omitempty"`
+	ImportTx   string `json:"importTx,omitempty"` // hex
+	ImportFee  uint64 `json:"importFee,omitempty"`
+	Imported   uint64 `json:"imported,omitempty"` // amount received on destination chain
+}
+
+// NewState returns state of transfer, that wasn't started yet
+func NewState(from, to string, amount uint64, address string) *State {
+	return &State{
+		From:    from,
+		To:      to,
+		Amount:  amount,
+		Address: address,
+		Step:    StepExport,
+	}
+}
+
+// LoadState reads state from file, returns nil state if file doesn't exist
+func LoadState(path string) (*State, error) {
+	stateBytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	state := &State{}
+	if err := json.Unmarshal(stateBytes, state); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return state, nil
+}
+
+// Matches returns error if state is progress of transfer with other parameters
+func (s *State) Matches(other *State) error {
+	if s.From != other.From || s.To != other.To || s.Amount != other.Amount || s.Address != other.Address {
+		return fmt.Errorf("%w: %s -> %s, %d nCAM from %s",
+			errStateMismatch, s.From, s.To, s.Amount, s.Address)
+	}
+	return nil
+}
+
+// Save writes state to file atomically, so interrupted save doesn't corrupt it
+func (s *State) Save(path string) error {
+	stateBytes, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(path, stateBytes)
+}
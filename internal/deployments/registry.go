@@ -0,0 +1,75 @@
+package deployments
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Deployment is record of deployed contract
+type Deployment struct {
+	Name        string    `json:"name"`
+	Address     string    `json:"address"`
+	TxHash      string    `json:"txHash"`
+	Deployer    string    `json:"deployer"`
+	BlockNumber uint64    `json:"blockNumber"`
+	Artifact    string    `json:"artifact"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// Registry is local json file with contracts deployed to one network
+type Registry struct {
+	path        string
+	Deployments []*Deployment `json:"deployments"`
+}
+
+// Load reads registry of network with given name from registry dir,
+// registry will be empty if its file doesn't exist yet
+func Load(dir, networkName string) (*Registry, error) {
+	registry := &Registry{path: filepath.Join(dir, networkName+".json")}
+	registryBytes, err := os.ReadFile(registry.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return registry, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(registryBytes, registry); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// Add adds deployment to registry and saves it
+func (r *Registry) Add(deployment *Deployment) error {
+	r.Deployments = append(r.Deployments, deployment)
+	return r.save()
+}
+
+// Latest returns the most recent deployment of contract with given name
+func (r *Registry) Latest(name string) (*Deployment, bool) {
+	for i := len(r.Deployments) - 1; i >= 0; i-- {
+		if r.Deployments[i].Name == name {
+			return r.Deployments[i], true
+		}
+	}
+	return nil, false
+}
+
+func (r *Registry) save() error {
+	registryBytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	// write to temp file first, so registry isn't corrupted if write is interrupted
+	tmpPath := r.path + ".tmp"
+	if err := os.WriteFile(tmpPath, registryBytes, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, r.path)
+}
@@ -0,0 +1,85 @@
+package errkind
+
+import "errors"
+
+// Kind is category of error, that callers can react to.
+// Kind is error itself, so errors.Is(err, errkind.InsufficientFunds) reports, if err is of that kind.
+type Kind string
+
+// Kinds of errors returned by node and node client
+const (
+	Unknown           Kind = "unknown"
+	InvalidInput      Kind = "invalid_input"
+	InsufficientFunds Kind = "insufficient_funds"
+	WrongNetwork      Kind = "wrong_network"
+	RPCUnavailable    Kind = "rpc_unavailable"
+	TxRejected        Kind = "tx_rejected"
+	SignatureMissing  Kind = "signature_missing"
+)
+
+func (k Kind) Error() string {
+	return string(k)
+}
+
+// Error is error of kind
+type Error struct {
+	Kind Kind
+	// Reason is why node rejected tx, it's only set for TxRejected
+	Reason string
+	Err    error
+}
+
+// New returns error of kind with message
+func New(kind Kind, msg string) error {
+	return &Error{Kind: kind, Err: errors.New(msg)}
+}
+
+// Wrap returns err as error of kind, nil if err is nil
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// Rejected returns err as TxRejected error with reason
+func Rejected(reason string, err error) error {
+	return &Error{Kind: TxRejected, Reason: reason, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	kind, ok := target.(Kind)
+	return ok && kind == e.Kind
+}
+
+// Of returns kind of first error of kind in err chain, Unknown if there is none
+func Of(err error) Kind {
+	var kindErr *Error
+	if errors.As(err, &kindErr) {
+		return kindErr.Kind
+	}
+	return Unknown
+}
+
+// ReasonOf returns reason of first rejection in err chain
+func ReasonOf(err error) string {
+	for err != nil {
+		var kindErr *Error
+		if !errors.As(err, &kindErr) {
+			return ""
+		}
+		if kindErr.Reason != "" {
+			return kindErr.Reason
+		}
+		err = kindErr.Err
+	}
+	return ""
+}
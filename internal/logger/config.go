@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Log formats
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+var errUnknownFormat = errors.New("unknown log format")
+
+// Config of logger
+type Config struct {
+	// Level is debug, info, warn or error
+	Level  string
+	Format string
+	// File is path of log file, logs are written to stderr if it's empty
+	File string
+	// MaxSize is size in megabytes, that log file is rotated at
+	MaxSize int
+	// MaxBackups is number of rotated log files, that are kept, all if 0
+	MaxBackups int
+	// MaxAge is number of days, that rotated log files are kept, forever if 0
+	MaxAge int
+}
+
+// NewZapLogger creates zap logger with config
+func NewZapLogger(cfg *Config) (*zap.SugaredLogger, error) {
+	level, err := zapcore.ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	var encoder zapcore.Encoder
+	switch cfg.Format {
+	case FormatConsole:
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	case FormatJSON:
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownFormat, cfg.Format)
+	}
+
+	writer := zapcore.Lock(os.Stderr)
+	if cfg.File != "" {
+		writer = zapcore.AddSync(&lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    cfg.MaxSize,
+			MaxBackups: cfg.MaxBackups,
+			MaxAge:     cfg.MaxAge,
+		})
+	}
+
+	return zap.New(
+		zapcore.NewCore(encoder, writer, level),
+		zap.AddCaller(),
+		zap.AddCallerSkip(1), // logger wrapper
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)),
+	).Sugar(), nil
+}
@@ -1,9 +1,14 @@
 package logger
 
-import "go.uber.org/zap"
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
 
 var _ Logger = (*logger)(nil)
 var _ Logger = (*noLog)(nil)
+var _ Logger = (*basicLogger)(nil)
 
 type Logger interface {
 	Debug(args ...interface{})
@@ -17,6 +22,23 @@ type Logger interface {
 	NoError(err error, msg ...string)
 }
 
+// BasicLogger is minimal logger, that library users implement. *zap.SugaredLogger implements it.
+type BasicLogger interface {
+	Debug(args ...interface{})
+	Debugf(template string, args ...interface{})
+	Info(args ...interface{})
+	Infof(template string, args ...interface{})
+	Error(args ...interface{})
+	Errorf(template string, args ...interface{})
+}
+
+// NewLoggerFromBasic returns logger, that masks private keys and mnemonics in messages logged with baseLogger.
+// It never exits or panics: Fatal is logged as error and NoError only logs non-nil error.
+func NewLoggerFromBasic(baseLogger BasicLogger) Logger {
+	return &basicLogger{baseLogger}
+}
+
+// NewLoggerFromZap returns logger, that masks private keys and mnemonics in messages logged with zapLogger
 func NewLoggerFromZap(zapLogger *zap.SugaredLogger) Logger {
 	return &logger{zapLogger}
 }
@@ -43,6 +65,38 @@ type logger struct {
 	*zap.SugaredLogger
 }
 
+func (l *logger) Debug(args ...interface{}) {
+	l.SugaredLogger.Debug(redact(fmt.Sprint(args...)))
+}
+
+func (l *logger) Debugf(template string, args ...interface{}) {
+	l.SugaredLogger.Debug(redact(fmt.Sprintf(template, args...)))
+}
+
+func (l *logger) Info(args ...interface{}) {
+	l.SugaredLogger.Info(redact(fmt.Sprint(args...)))
+}
+
+func (l *logger) Infof(template string, args ...interface{}) {
+	l.SugaredLogger.Info(redact(fmt.Sprintf(template, args...)))
+}
+
+func (l *logger) Error(args ...interface{}) {
+	l.SugaredLogger.Error(redact(fmt.Sprint(args...)))
+}
+
+func (l *logger) Errorf(template string, args ...interface{}) {
+	l.SugaredLogger.Error(redact(fmt.Sprintf(template, args...)))
+}
+
+func (l *logger) Fatal(args ...interface{}) {
+	l.SugaredLogger.Fatal(redact(fmt.Sprint(args...)))
+}
+
+func (l *logger) Fatalf(template string, args ...interface{}) {
+	l.SugaredLogger.Fatal(redact(fmt.Sprintf(template, args...)))
+}
+
 func (l *logger) NoError(err error, msg ...string) {
 	if err != nil {
 		if len(msg) > 0 {
@@ -51,3 +105,49 @@ func (l *logger) NoError(err error, msg ...string) {
 		l.Fatalf("\n\nERROR: %v\n\n", err)
 	}
 }
+
+type basicLogger struct {
+	BasicLogger
+}
+
+func (l *basicLogger) Debug(args ...interface{}) {
+	l.BasicLogger.Debug(redact(fmt.Sprint(args...)))
+}
+
+func (l *basicLogger) Debugf(template string, args ...interface{}) {
+	l.BasicLogger.Debug(redact(fmt.Sprintf(template, args...)))
+}
+
+func (l *basicLogger) Info(args ...interface{}) {
+	l.BasicLogger.Info(redact(fmt.Sprint(args...)))
+}
+
+func (l *basicLogger) Infof(template string, args ...interface{}) {
+	l.BasicLogger.Info(redact(fmt.Sprintf(template, args...)))
+}
+
+func (l *basicLogger) Error(args ...interface{}) {
+	l.BasicLogger.Error(redact(fmt.Sprint(args...)))
+}
+
+func (l *basicLogger) Errorf(template string, args ...interface{}) {
+	l.BasicLogger.Error(redact(fmt.Sprintf(template, args...)))
+}
+
+func (l *basicLogger) Fatal(args ...interface{}) {
+	l.Error(args...)
+}
+
+func (l *basicLogger) Fatalf(template string, args ...interface{}) {
+	l.Errorf(template, args...)
+}
+
+func (l *basicLogger) NoError(err error, msg ...string) {
+	if err != nil {
+		if len(msg) > 0 {
+			l.Errorf("%v: %v", msg, err)
+			return
+		}
+		l.Error(err)
+	}
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+const (
+	redacted = "[REDACTED]"
+	// minMnemonicWords is length of shortest bip-39 mnemonic
+	minMnemonicWords = 12
+)
+
+var (
+	// avax private key, e.g. PrivateKey-ewoqjP7PxY4yr3iLTpLisriqt94hdyDFNgchSxGGztUrTXtNN
+	privateKeyRegexp = regexp.MustCompile(`PrivateKey-[1-9A-HJ-NP-Za-km-z]+`)
+	// hex private key, that is labelled as key or secret, e.g. "private_key": "0x..." or --private-key 0x...
+	// Unlabelled 32 bytes hex isn't masked, it can't be told apart from C-Chain tx and block hashes.
+	hexKeyRegexp = regexp.MustCompile(`(?i)((?:private[_ -]?key|secret)["']?(?:\s*[:=]\s*|\s+)["']?)(?:0x)?[0-9a-f]{64}\b`)
+	wordRegexp   = regexp.MustCompile(`\b[a-z]+\b`)
+
+	mnemonicWords = func() map[string]struct{} {
+		words := make(map[string]struct{}, len(wordlists.English))
+		for _, word := range wordlists.English {
+			words[word] = struct{}{}
+		}
+		return words
+	}()
+)
+
+// redact masks private keys and bip-39 mnemonics in log message
+func redact(msg string) string {
+	msg = privateKeyRegexp.ReplaceAllString(msg, "PrivateKey-"+redacted)
+	msg = hexKeyRegexp.ReplaceAllString(msg, "${1}"+redacted)
+	return redactMnemonics(msg)
+}
+
+// redactMnemonics masks runs of at least minMnemonicWords bip-39 words separated by whitespace
+func redactMnemonics(msg string) string {
+	words := wordRegexp.FindAllStringIndex(msg, -1)
+	if len(words) < minMnemonicWords {
+		return msg
+	}
+	var (
+		b      strings.Builder
+		last   int // end of msg part, that is already written
+		streak int // number of mnemonic words before current one
+	)
+	for i := 0; i <= len(words); i++ {
+		isMnemonicWord := false
+		if i < len(words) {
+			_, isMnemonicWord = mnemonicWords[msg[words[i][0]:words[i][1]]]
+		}
+		if isMnemonicWord && (streak == 0 || strings.TrimSpace(msg[words[i-1][1]:words[i][0]]) == "") {
+			streak++
+			continue
+		}
+		if streak >= minMnemonicWords {
+			b.WriteString(msg[last:words[i-streak][0]])
+			b.WriteString(redacted)
+			last = words[i-1][1]
+		}
+		streak = 0
+		if isMnemonicWord {
+			streak = 1
+		}
+	}
+	if last == 0 {
+		return msg
+	}
+	b.WriteString(msg[last:])
+	return b.String()
+}
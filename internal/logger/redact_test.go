@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	testMnemonic12 = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	testMnemonic24 = "legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth title"
+	testHexKey     = "56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5ccf558d8027"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      string
+		expected string
+	}{
+		{
+			name:     "private key",
+			msg:      "key: PrivateKey-ewoqjP7PxY4yr3iLTpLisriqt94hdyDFNgchSxGGztUrTXtNN, address: P-kopernikus18jma8ppw3nhx5r4ap8clazz0dps7rv5uuvjh68",
+			expected: "key: PrivateKey-" + redacted + ", address: P-kopernikus18jma8ppw3nhx5r4ap8clazz0dps7rv5uuvjh68",
+		},
+		{
+			name:     "labelled hex key in json",
+			msg:      `{"private_key": "0x` + testHexKey + `"}`,
+			expected: `{"private_key": "` + redacted + `"}`,
+		},
+		{
+			name:     "labelled hex key without 0x",
+			msg:      "secret=" + testHexKey,
+			expected: "secret=" + redacted,
+		},
+		{
+			name:     "hex key flag",
+			msg:      "--private-key 0x" + testHexKey + " --network kopernikus",
+			expected: "--private-key " + redacted + " --network kopernikus",
+		},
+		{
+			name:     "labelled hex shorter than key",
+			msg:      "private key: 0x" + testHexKey[2:],
+			expected: "private key: 0x" + testHexKey[2:],
+		},
+		{
+			name:     "labelled hex longer than key",
+			msg:      "private key: 0x" + testHexKey + "00",
+			expected: "private key: 0x" + testHexKey + "00",
+		},
+		// unlabelled 32 bytes hex is kept, it's usually tx or block hash
+		{
+			name:     "unlabelled hex",
+			msg:      "txID: 0x" + testHexKey + " (nonce 1)",
+			expected: "txID: 0x" + testHexKey + " (nonce 1)",
+		},
+		{
+			name:     "12 words mnemonic",
+			msg:      "mnemonic: " + testMnemonic12 + "\n",
+			expected: "mnemonic: " + redacted + "\n",
+		},
+		{
+			name:     "24 words mnemonic",
+			msg:      "restoring wallet from " + testMnemonic24 + ", index 0",
+			expected: "restoring wallet from " + redacted + ", index 0",
+		},
+		{
+			name:     "mnemonic split by lines",
+			msg:      strings.Join(strings.Fields(testMnemonic24)[:12], " ") + "\n" + strings.Join(strings.Fields(testMnemonic24)[12:], " "),
+			expected: redacted,
+		},
+		{
+			name:     "11 mnemonic words",
+			msg:      strings.Join(strings.Fields(testMnemonic12)[:11], " "),
+			expected: strings.Join(strings.Fields(testMnemonic12)[:11], " "),
+		},
+		{
+			name:     "mnemonic words separated by punctuation",
+			msg:      strings.ReplaceAll(testMnemonic12, " ", ", "),
+			expected: strings.ReplaceAll(testMnemonic12, " ", ", "),
+		},
+		{
+			name:     "normal sentence",
+			msg:      "Waiting for P-Chain tx to be accepted, it can take a few seconds before the node reports its final status",
+			expected: "Waiting for P-Chain tx to be accepted, it can take a few seconds before the node reports its final status",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if redactedMsg := redact(tt.msg); redactedMsg != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, redactedMsg)
+			}
+		})
+	}
+}
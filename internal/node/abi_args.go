@@ -0,0 +1,157 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	errWrongArgsCount    = errkind.New(errkind.InvalidInput, "wrong number of abi arguments")
+	errInvalidABIArg     = errkind.New(errkind.InvalidInput, "invalid abi argument")
+	errUnsupportedABIArg = errkind.New(errkind.InvalidInput, "unsupported abi argument type")
+)
+
+// ParseABIArgs converts string arguments (e.g. from command line) into
+// go values of types expected by abi packing. Arrays and slices are passed
+// as json arrays, e.g. ["0x...", "0x..."].
+func ParseABIArgs(inputs abi.Arguments, args []string) ([]interface{}, error) {
+	if len(inputs) != len(args) {
+		return nil, fmt.Errorf("%w: expected %d, got %d", errWrongArgsCount, len(inputs), len(args))
+	}
+	values := make([]interface{}, len(args))
+	for i, input := range inputs {
+		value, err := parseABIValue(input.Type, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %q: %w", input.Name, err)
+		}
+		values[i] = value.Interface()
+	}
+	return values, nil
+}
+
+func parseABIValue(t abi.Type, str string) (reflect.Value, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		return parseABIInt(t, str)
+	case abi.BoolTy:
+		b, err := strconv.ParseBool(str)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%w: %s", errInvalidABIArg, err)
+		}
+		return reflect.ValueOf(b), nil
+	case abi.StringTy:
+		return reflect.ValueOf(str), nil
+	case abi.AddressTy:
+		if !common.IsHexAddress(str) {
+			return reflect.Value{}, fmt.Errorf("%w: %q is not an address", errInvalidABIArg, str)
+		}
+		return reflect.ValueOf(common.HexToAddress(str)), nil
+	case abi.BytesTy:
+		b, err := hexutil.Decode(str)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%w: %s", errInvalidABIArg, err)
+		}
+		return reflect.ValueOf(b), nil
+	case abi.FixedBytesTy, abi.HashTy:
+		b, err := hexutil.Decode(str)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%w: %s", errInvalidABIArg, err)
+		}
+		value := reflect.New(t.GetType()).Elem()
+		if len(b) != value.Len() {
+			return reflect.Value{}, fmt.Errorf("%w: expected %d bytes, got %d", errInvalidABIArg, value.Len(), len(b))
+		}
+		reflect.Copy(value, reflect.ValueOf(b))
+		return value, nil
+	case abi.SliceTy, abi.ArrayTy:
+		elems, err := splitJSONArray(str)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		var value reflect.Value
+		if t.T == abi.SliceTy {
+			value = reflect.MakeSlice(t.GetType(), len(elems), len(elems))
+		} else {
+			value = reflect.New(t.GetType()).Elem()
+			if len(elems) != t.Size {
+				return reflect.Value{}, fmt.Errorf("%w: expected %d elements, got %d", errInvalidABIArg, t.Size, len(elems))
+			}
+		}
+		for i, elem := range elems {
+			elemValue, err := parseABIValue(*t.Elem, elem)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			value.Index(i).Set(elemValue)
+		}
+		return value, nil
+	}
+	return reflect.Value{}, fmt.Errorf("%w: %s", errUnsupportedABIArg, t)
+}
+
+func parseABIInt(t abi.Type, str string) (reflect.Value, error) {
+	n, ok := new(big.Int).SetString(str, 0)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%w: %q is not an integer", errInvalidABIArg, str)
+	}
+	goType := t.GetType()
+	if goType == reflect.TypeOf(n) {
+		// abi packs *big.Int as two's complement, so out of range value
+		// (e.g. negative uint256) would be silently packed as different number
+		if !bigIntFits(t, n) {
+			return reflect.Value{}, fmt.Errorf("%w: %s overflows %s", errInvalidABIArg, str, t)
+		}
+		return reflect.ValueOf(n), nil
+	}
+	value := reflect.New(goType).Elem()
+	switch {
+	case t.T == abi.UintTy && n.IsUint64() && !value.OverflowUint(n.Uint64()):
+		value.SetUint(n.Uint64())
+	case t.T == abi.IntTy && n.IsInt64() && !value.OverflowInt(n.Int64()):
+		value.SetInt(n.Int64())
+	default:
+		return reflect.Value{}, fmt.Errorf("%w: %s overflows %s", errInvalidABIArg, str, t)
+	}
+	return value, nil
+}
+
+// bigIntFits reports, if n is in range of t integer type
+func bigIntFits(t abi.Type, n *big.Int) bool {
+	if t.T == abi.UintTy {
+		return n.Sign() >= 0 && n.BitLen() <= t.Size
+	}
+	if n.Sign() < 0 {
+		// -2^(size-1) is the least value, so -n-1 must fit into size-1 bits
+		return new(big.Int).Not(n).BitLen() < t.Size
+	}
+	return n.BitLen() < t.Size
+}
+
+// splitJSONArray splits json array into its elements,
+// string elements are unquoted, others are kept as raw json
+func splitJSONArray(str string) ([]string, error) {
+	rawElems := []json.RawMessage{}
+	if err := json.Unmarshal([]byte(str), &rawElems); err != nil {
+		return nil, fmt.Errorf("%w: %q is not a json array", errInvalidABIArg, str)
+	}
+	elems := make([]string, len(rawElems))
+	for i, rawElem := range rawElems {
+		elems[i] = strings.TrimSpace(string(rawElem))
+		if strings.HasPrefix(elems[i], "\"") {
+			if err := json.Unmarshal(rawElem, &elems[i]); err != nil {
+				return nil, fmt.Errorf("%w: %s", errInvalidABIArg, err)
+			}
+		}
+	}
+	return elems, nil
+}
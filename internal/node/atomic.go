@@ -0,0 +1,406 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	errNoAtomicUTXOs        = errkind.New(errkind.InsufficientFunds, "no atomic utxos to import")
+	errImportBelowFee       = errkind.New(errkind.InsufficientFunds, "imported amount doesn't cover import fee")
+	errUnsupportedAtomicDir = errkind.New(errkind.InvalidInput, "unsupported atomic transfer chain")
+)
+
+// PExportTx creates P-Chain ExportTx, that sends amount from fundsKey unlocked utxos
+// to recipientAddr on targetChain. Exported funds must be imported on targetChain.
+func (c *Client) PExportTx(
+	ctx context.Context,
+	amount uint64,
+	recipientAddr ids.ShortID,
+	fundsKey Signer,
+	targetChain string,
+	opts ...PSpendOption,
+) (*PTxResult, error) {
+	c.logger.Info("Creating P-Chain ExportTx...")
+	if amount == 0 {
+		c.logger.Error(errZeroTransferAmount)
+		return nil, errZeroTransferAmount
+	}
+	if targetChain != "C" {
+		err := fmt.Errorf("%w: P -> %s", errUnsupportedAtomicDir, targetChain)
+		c.logger.Error(err)
+		return nil, err
+	}
+	destinationChainID, err := c.getChainID(targetChain)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	fee := getNetworkVMParams(c.networkID).TxFee
+	amountToBurn, err := math.Add64(amount, fee)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	if err := c.checkUnlockedBalance(ctx, fundsKey, amountToBurn, opts...); err != nil {
+		return nil, err
+	}
+
+	spending, signers, err := c.spendP(ctx, fundsKey, 0, amountToBurn, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer spending.release()
+
+	exportedOuts := []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: c.avaxAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{recipientAddr},
+			},
+		},
+	}}
+
+	tx, err := c.signPTx(ctx, &pTxs.ExportTx{
+		BaseTx: pTxs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    c.networkID,
+			BlockchainID: constants.PlatformChainID,
+			Ins:          spending.ins,
+			Outs:         spending.outs,
+		}},
+		DestinationChain: destinationChainID,
+		ExportedOutputs:  exportedOuts,
+	}, signers)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	spending.commit(tx)
+	return c.newPTxResult("ExportTx", tx, spending.ins, append(spending.outs, exportedOuts...))
+}
+
+// PImportTx creates P-Chain ImportTx, that imports key atomic utxos exported from sourceChain
+// to key address, fee is deducted from imported amount.
+// If sourceTxID isn't empty, only outputs of that tx are imported.
+func (c *Client) PImportTx(ctx context.Context, sourceChain string, sourceTxID ids.ID, key Signer) (*PTxResult, error) {
+	c.logger.Info("Creating P-Chain ImportTx...")
+	if sourceChain != "C" {
+		err := fmt.Errorf("%w: %s -> P", errUnsupportedAtomicDir, sourceChain)
+		c.logger.Error(err)
+		return nil, err
+	}
+	sourceChainID, err := c.getChainID(sourceChain)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	utxos, err := c.getPAtomicUTXOs(ctx, key.Address(), sourceChain)
+	if err != nil {
+		return nil, err
+	}
+	ins, signers, amount, err := c.atomicInputs(utxos, sourceTxID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := getNetworkVMParams(c.networkID).TxFee
+	if amount <= fee {
+		err := fmt.Errorf("%w: imported %d, fee %d", errImportBelowFee, amount, fee)
+		c.logger.Error(err)
+		return nil, err
+	}
+	outs := []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: c.avaxAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount - fee,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{key.Address()},
+			},
+		},
+	}}
+
+	tx, err := c.signPTx(ctx, &pTxs.ImportTx{
+		BaseTx: pTxs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    c.networkID,
+			BlockchainID: constants.PlatformChainID,
+			Outs:         outs,
+		}},
+		SourceChain:    sourceChainID,
+		ImportedInputs: ins,
+	}, signers)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	return c.newPTxResult("ImportTx", tx, ins, outs)
+}
+
+// CImportTx creates C-Chain ImportTx, that imports key atomic utxos exported from sourceChain
+// to recipientAddr evm balance, fee is deducted from imported amount.
+// If sourceTxID isn't empty, only outputs of that tx are imported.
+func (c *Client) CImportTx(
+	ctx context.Context,
+	sourceChain string,
+	sourceTxID ids.ID,
+	key Signer,
+	recipientAddr common.Address,
+) (*CTxResult, error) {
+	c.logger.Info("Creating C-Chain ImportTx...")
+	if sourceChain != "P" {
+		err := fmt.Errorf("%w: %s -> C", errUnsupportedAtomicDir, sourceChain)
+		c.logger.Error(err)
+		return nil, err
+	}
+	sourceChainID, err := c.getChainID(sourceChain)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	utxos, err := c.getCAtomicUTXOs(ctx, key.Address(), sourceChain)
+	if err != nil {
+		return nil, err
+	}
+	ins, signers, amount, err := c.atomicInputs(utxos, sourceTxID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	newImportTx := func(fee uint64) *evm.Tx {
+		return &evm.Tx{UnsignedAtomicTx: &evm.UnsignedImportTx{
+			NetworkID:      c.networkID,
+			BlockchainID:   c.cChainID,
+			SourceChain:    sourceChainID,
+			ImportedInputs: ins,
+			Outs: []evm.EVMOutput{{
+				Address: recipientAddr,
+				Amount:  amount - fee,
+				AssetID: c.avaxAssetID,
+			}},
+		}}
+	}
+
+	// calculate fee with tx of the same size, its signatures are empty
+
+	tx := newImportTx(0)
+	for _, credSigners := range signers {
+		tx.Creds = append(tx.Creds, &secp256k1fx.Credential{Sigs: make([][secp256k1.SignatureLen]byte, len(credSigners))})
+	}
+	if err := tx.Sign(evm.Codec, nil); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	txGasUsed, err := tx.GasUsed(true)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	var baseFee *big.Int
+	if err := c.client.ReadEVM(ctx, func(ctx context.Context, eth ethclient.Client) error {
+		var err error
+		baseFee, err = eth.EstimateBaseFee(ctx)
+		return err
+	}); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	fee, err := calculateEVMDynamicFee(txGasUsed, baseFee)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	if amount <= fee {
+		err := fmt.Errorf("%w: imported %d, fee %d", errImportBelowFee, amount, fee)
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	// create tx
+
+	tx = newImportTx(fee)
+	if err := c.signCTx(tx, signers); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	result, err := c.newCTxResult("ImportTx", tx, nil, "C", nil)
+	if err != nil {
+		return nil, err
+	}
+	// imported funds are evm balance, not utxos
+	result.Fee = fee
+	result.Consumed = make([]string, len(ins))
+	for i, in := range ins {
+		result.Consumed[i] = in.UTXOID.String()
+	}
+	result.Produced = []*TxOutput{{
+		AssetID:   c.avaxAssetID,
+		Amount:    amount - fee,
+		Threshold: 1,
+		Owners:    []string{recipientAddr.Hex()},
+	}}
+	return result, nil
+}
+
+// atomicInputs returns sorted inputs, that spend unlocked avax utxos, which key can spend alone,
+// their signers and total amount. If sourceTxID isn't empty, only its outputs are spent.
+func (c *Client) atomicInputs(
+	utxos []*avax.UTXO,
+	sourceTxID ids.ID,
+	key Signer,
+) ([]*avax.TransferableInput, [][]Signer, uint64, error) {
+	now := uint64(time.Now().Unix())
+	ins := []*avax.TransferableInput{}
+	amount := uint64(0)
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok || utxo.AssetID() != c.avaxAssetID || out.Locktime > now ||
+			(sourceTxID != ids.Empty && utxo.TxID != sourceTxID) {
+			continue
+		}
+		sigIndices, _, err := ownerSigIndices(out.Addrs, out.Threshold, []Signer{key})
+		if err != nil {
+			continue
+		}
+		newAmount, err := math.Add64(amount, out.Amt)
+		if err != nil {
+			c.logger.Error(err)
+			return nil, nil, 0, err
+		}
+		amount = newAmount
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  utxo.Asset,
+			In: &secp256k1fx.TransferInput{
+				Amt:   out.Amt,
+				Input: secp256k1fx.Input{SigIndices: sigIndices},
+			},
+		})
+	}
+	if len(ins) == 0 {
+		c.logger.Error(errNoAtomicUTXOs)
+		return nil, nil, 0, errNoAtomicUTXOs
+	}
+	// every input is signed by key alone, so signers don't have to be sorted with inputs
+	avax.SortTransferableInputs(ins)
+	signers := make([][]Signer, len(ins))
+	for i := range signers {
+		signers[i] = []Signer{key}
+	}
+	return ins, signers, amount, nil
+}
+
+// addCTxSignatures adds credentials with signatures of signers to tx, as evm.Tx.Sign does with keys
+func addCTxSignatures(tx *evm.Tx, signers [][]Signer) error {
+	// signing without keys initializes unsigned tx bytes, which hash is signed
+	if err := tx.Sign(evm.Codec, nil); err != nil {
+		return err
+	}
+	hash := hashing.ComputeHash256(tx.UnsignedAtomicTx.Bytes())
+	for _, credSigners := range signers {
+		cred := &secp256k1fx.Credential{Sigs: make([][secp256k1.SignatureLen]byte, len(credSigners))}
+		for i, signer := range credSigners {
+			sig, err := signer.SignHash(hash)
+			if err != nil {
+				return err
+			}
+			copy(cred.Sigs[i][:], sig)
+		}
+		tx.Creds = append(tx.Creds, cred)
+	}
+	// and then signed tx bytes with added credentials
+	return tx.Sign(evm.Codec, nil)
+}
+
+// getPAtomicUTXOs fetches all P-Chain atomic utxos of address exported from sourceChain
+func (c *Client) getPAtomicUTXOs(ctx context.Context, addr ids.ShortID, sourceChain string) ([]*avax.UTXO, error) {
+	var utxosBytes [][]byte
+	startAddr, startUTXOID := ids.ShortEmpty, ids.Empty
+	for {
+		var (
+			pageBytes [][]byte
+			endAddr   ids.ShortID
+			endUTXOID ids.ID
+		)
+		if err := c.client.ReadP(ctx, func(ctx context.Context, p platformvm.Client) error {
+			var err error
+			pageBytes, endAddr, endUTXOID, err = p.GetAtomicUTXOs(ctx, []ids.ShortID{addr}, sourceChain, utxosPageSize, startAddr, startUTXOID)
+			return err
+		}); err != nil {
+			c.logger.Error(err)
+			return nil, err
+		}
+		utxosBytes = append(utxosBytes, pageBytes...)
+		if len(pageBytes) < utxosPageSize {
+			return c.parseUTXOs(pTxs.Codec, utxosBytes)
+		}
+		startAddr, startUTXOID = endAddr, endUTXOID
+	}
+}
+
+// getCAtomicUTXOs fetches all C-Chain atomic utxos of address exported from sourceChain
+func (c *Client) getCAtomicUTXOs(ctx context.Context, addr ids.ShortID, sourceChain string) ([]*avax.UTXO, error) {
+	addrStr, err := address.Format("C", c.hrp, addr.Bytes())
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	var utxosBytes [][]byte
+	startIndex := api.Index{}
+	for {
+		var (
+			pageBytes [][]byte
+			endIndex  api.Index
+		)
+		if err := c.client.ReadC(ctx, func(ctx context.Context, cClient evm.Client) error {
+			var err error
+			pageBytes, endIndex, err = cClient.GetAtomicUTXOs(ctx, []string{addrStr}, sourceChain, utxosPageSize, startIndex.Address, startIndex.UTXO)
+			return err
+		}); err != nil {
+			c.logger.Error(err)
+			return nil, err
+		}
+		utxosBytes = append(utxosBytes, pageBytes...)
+		if len(pageBytes) < utxosPageSize {
+			return c.parseUTXOs(evm.Codec, utxosBytes)
+		}
+		startIndex = endIndex
+	}
+}
+
+func (c *Client) parseUTXOs(codecManager codec.Manager, utxosBytes [][]byte) ([]*avax.UTXO, error) {
+	utxos := make([]*avax.UTXO, len(utxosBytes))
+	for i, utxoBytes := range utxosBytes {
+		utxos[i] = &avax.UTXO{}
+		if _, err := codecManager.Unmarshal(utxoBytes, utxos[i]); err != nil {
+			c.logger.Error(err)
+			return nil, err
+		}
+	}
+	return utxos, nil
+}
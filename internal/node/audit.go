@@ -0,0 +1,161 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"caminoclient/internal/audit"
+	"caminoclient/internal/policy"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/plugin/evm"
+)
+
+// SetAuditLog makes client record every tx it signs or issues to audit log
+func (c *Client) SetAuditLog(auditLog *audit.Log) {
+	c.audit = auditLog
+}
+
+// auditTx records tx described by signing request to audit log, if client has it
+func (c *Client) auditTx(event string, req *policy.Request, txID ids.ID, txBytes []byte) error {
+	if c.audit == nil {
+		return nil
+	}
+	txHex, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return err
+	}
+	entry := &audit.Entry{
+		Event:     event,
+		NetworkID: req.NetworkID,
+		Chain:     req.Chain,
+		TxType:    req.TxType,
+		TxID:      txID,
+		Signers:   make([]string, len(req.Keys)),
+		Amount:    req.Amount,
+		Tx:        txHex,
+	}
+	for i, signer := range req.Keys {
+		if entry.Signers[i], err = address.Format(req.Chain, c.hrp, signer[:]); err != nil {
+			return err
+		}
+	}
+	return c.audit.Append(entry)
+}
+
+// issuedTx describes tx, that is issued
+type issuedTx struct {
+	req           *policy.Request
+	id            ids.ID
+	unsignedBytes []byte // policy reservations of tx signing are held by them
+}
+
+// issuedPTx describes issued P-Chain tx with signers recovered from its signatures
+func (c *Client) issuedPTx(ctx context.Context, txBytes []byte) (*issuedTx, error) {
+	tx, err := pTxs.Parse(pTxs.Codec, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	signers, err := recoverTxSigners(tx.Unsigned.Bytes(), tx.Creds)
+	if err != nil {
+		return nil, err
+	}
+	signerAddrs := make([]ids.ShortID, len(signers))
+	for i, signer := range signers {
+		signerAddrs[i] = signer.Address()
+	}
+	req, err := c.pSigningRequest(ctx, tx.Unsigned, [][]ids.ShortID{signerAddrs}, signerAddrs)
+	if err != nil {
+		return nil, err
+	}
+	return &issuedTx{req: req, id: tx.ID(), unsignedBytes: tx.Unsigned.Bytes()}, nil
+}
+
+// issuedCTx describes issued C-Chain atomic tx with signers recovered from its signatures
+func (c *Client) issuedCTx(txBytes []byte) (*issuedTx, error) {
+	tx := &evm.Tx{}
+	if _, err := evm.Codec.Unmarshal(txBytes, tx); err != nil {
+		return nil, err
+	}
+	// signing without signers only initializes tx bytes
+	if err := tx.Sign(evm.Codec, nil); err != nil {
+		return nil, err
+	}
+	signers, err := recoverTxSigners(tx.UnsignedAtomicTx.Bytes(), tx.Creds)
+	if err != nil {
+		return nil, err
+	}
+	return &issuedTx{
+		req:           c.cSigningRequest(tx.UnsignedAtomicTx, signers),
+		id:            tx.ID(),
+		unsignedBytes: tx.UnsignedAtomicTx.Bytes(),
+	}, nil
+}
+
+// issueAudited records issuing entry, issues tx, records issued entry and settles policy
+// reservations of tx signing. Tx isn't issued, if issuing entry can't be recorded.
+// Failures to record issued entry or to save reserved amount are only logged,
+// because tx is already issued and its issuing entry is in audit log.
+func (c *Client) issueAudited(
+	txBytes []byte,
+	describe func() (*issuedTx, error),
+	issue func() error,
+) error {
+	if c.audit == nil && c.policy == nil {
+		return issue()
+	}
+	tx, err := describe()
+	if err != nil {
+		return err
+	}
+	if err := c.auditTx(audit.EventIssuing, tx.req, tx.id, txBytes); err != nil {
+		_ = c.settleReservations(tx, err)
+		return err
+	}
+	issueErr := issue()
+	if err := c.settleReservations(tx, issueErr); err != nil {
+		c.logger.Errorf("%v: %v", errIssuedNotCommitted, err)
+	}
+	if issueErr != nil {
+		return issueErr
+	}
+	if err := c.auditTx(audit.EventIssued, tx.req, tx.id, txBytes); err != nil {
+		c.logger.Errorf("%v: %v", errIssuedNotAudited, err)
+	}
+	return nil
+}
+
+// recoverTxSigners recovers public keys of all tx signatures, empty signatures are skipped
+func recoverTxSigners(unsignedBytes []byte, creds []verify.Verifiable) ([]*secp256k1.PublicKey, error) {
+	factory := secp256k1.Factory{}
+	hash := hashing.ComputeHash256(unsignedBytes)
+	signers := []*secp256k1.PublicKey{}
+	seen := map[ids.ShortID]struct{}{}
+	for i, cred := range creds {
+		secpCred, ok := cred.(*secp256k1fx.Credential)
+		if !ok {
+			return nil, fmt.Errorf("%w: credential %d", errUnexpectedCred, i)
+		}
+		for _, sig := range secpCred.Sigs {
+			if sig == [secp256k1.SignatureLen]byte{} {
+				continue
+			}
+			pubKey, err := factory.RecoverHashPublicKey(hash, sig[:])
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := seen[pubKey.Address()]; !ok {
+				seen[pubKey.Address()] = struct{}{}
+				signers = append(signers, pubKey)
+			}
+		}
+	}
+	return signers, nil
+}
@@ -0,0 +1,230 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"caminoclient/internal/audit"
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errNoAuthKeys        = errkind.New(errkind.InvalidInput, "authority has no keys and no signers")
+	errNotAliasSigner    = errkind.New(errkind.InvalidInput, "signer is not alias owner")
+	errWrongSignersCount = errkind.New(errkind.InvalidInput, "number of signers must be equal to alias threshold")
+	errPartialTxPool     = errkind.New(errkind.InvalidInput, "partially signed tx can't spend utxo pool")
+	errUnexpectedCred    = errkind.New(errkind.InvalidInput, "unexpected credential type")
+)
+
+// Authority is address, that authorizes tx as executor, proposer or voter, and keys of its signers.
+// Address is either address of single key or multisig alias, which direct owners sign tx.
+type Authority struct {
+	Address ids.ShortID
+	Keys    []Signer
+	// Signers are alias owners, that sign tx. If empty, owners, which keys are given, are used.
+	// Signers without keys don't sign tx, it must be completed by them with SignPartialPTx.
+	Signers []ids.ShortID
+}
+
+// KeyAuthority returns authority of single key
+func KeyAuthority(key Signer) *Authority {
+	return &Authority{Address: key.Address(), Keys: []Signer{key}}
+}
+
+// resolveAuth returns auth input and addresses of its signers in signature order
+func (c *Client) resolveAuth(ctx context.Context, auth *Authority) (*secp256k1fx.Input, []ids.ShortID, error) {
+	if len(auth.Signers) == 0 {
+		for _, key := range auth.Keys {
+			if key.Address() == auth.Address {
+				return &secp256k1fx.Input{SigIndices: []uint32{0}}, []ids.ShortID{auth.Address}, nil
+			}
+		}
+	}
+	if len(auth.Keys) == 0 && len(auth.Signers) == 0 {
+		return nil, nil, errNoAuthKeys
+	}
+
+	alias, err := c.getMultisigAlias(ctx, auth.Address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(auth.Signers) == 0 {
+		sigIndices, signers, err := ownerSigIndices(alias.OwnerIDs, alias.Threshold, auth.Keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		signerAddrs := make([]ids.ShortID, len(signers))
+		for i, signer := range signers {
+			signerAddrs[i] = signer.Address()
+		}
+		return &secp256k1fx.Input{SigIndices: sigIndices}, signerAddrs, nil
+	}
+
+	if uint32(len(auth.Signers)) != alias.Threshold {
+		return nil, nil, fmt.Errorf("%w: %d signers, threshold %d", errWrongSignersCount, len(auth.Signers), alias.Threshold)
+	}
+	ownerIndices := make(map[ids.ShortID]uint32, len(alias.OwnerIDs))
+	for i, owner := range alias.OwnerIDs {
+		ownerIndices[owner] = uint32(i)
+	}
+	// sig indices must be sorted, owners are
+	sigIndices := make([]uint32, 0, len(auth.Signers))
+	signerAddrs := make([]ids.ShortID, 0, len(auth.Signers))
+	signersSet := make(map[ids.ShortID]struct{}, len(auth.Signers))
+	for _, signer := range auth.Signers {
+		if _, ok := ownerIndices[signer]; !ok {
+			return nil, nil, fmt.Errorf("%w: %s", errNotAliasSigner, signer)
+		}
+		signersSet[signer] = struct{}{}
+	}
+	if len(signersSet) != len(auth.Signers) {
+		return nil, nil, errDuplicateOwner
+	}
+	for i, owner := range alias.OwnerIDs {
+		if _, ok := signersSet[owner]; ok {
+			sigIndices = append(sigIndices, uint32(i))
+			signerAddrs = append(signerAddrs, owner)
+		}
+	}
+	return &secp256k1fx.Input{SigIndices: sigIndices}, signerAddrs, nil
+}
+
+// signAuthPTx signs tx inputs with inputSigners and its auth credential with auth keys,
+// once signing policy authorizes it. Signatures of auth signers without keys are left empty.
+func (c *Client) signAuthPTx(
+	ctx context.Context,
+	utx pTxs.UnsignedTx,
+	inputSigners [][]Signer,
+	authSigners []ids.ShortID,
+	auth *Authority,
+) (*pTxs.Tx, [][]ids.ShortID, error) {
+	signers := make([][]ids.ShortID, 0, len(inputSigners)+1)
+	keys := append([]Signer{}, auth.Keys...)
+	for _, inputKeys := range inputSigners {
+		signers = append(signers, keyAddrs(inputKeys))
+		keys = append(keys, inputKeys...)
+	}
+	signers = append(signers, authSigners)
+
+	req, err := c.pSigningRequest(ctx, utx, signers, keyAddrs(keys))
+	if err != nil {
+		return nil, nil, err
+	}
+	reservation, err := c.authorize(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx := newUnsignedPTx(utx, signers)
+	if _, err := addPTxSignatures(tx, signers, keys); err != nil {
+		reservation.Release()
+		return nil, nil, err
+	}
+	if err := c.auditTx(audit.EventSigned, req, tx.ID(), tx.Bytes()); err != nil {
+		reservation.Release()
+		return nil, nil, err
+	}
+	c.holdReservation(tx.Unsigned.Bytes(), reservation)
+	return tx, signers, nil
+}
+
+// newUnsignedPTx returns tx with credentials of signers, which signatures are empty
+func newUnsignedPTx(utx pTxs.UnsignedTx, signers [][]ids.ShortID) *pTxs.Tx {
+	tx := &pTxs.Tx{Unsigned: utx, Creds: make([]verify.Verifiable, len(signers))}
+	for i, credSigners := range signers {
+		tx.Creds[i] = &secp256k1fx.Credential{Sigs: make([][secp256k1.SignatureLen]byte, len(credSigners))}
+	}
+	return tx
+}
+
+// addPTxSignatures adds missing signatures of signers, which keys are given, and returns number of added ones
+func addPTxSignatures[S Signer](tx *pTxs.Tx, signers [][]ids.ShortID, keys []S) (int, error) {
+	unsignedBytes, err := pTxs.Codec.Marshal(pTxs.Version, &tx.Unsigned)
+	if err != nil {
+		return 0, err
+	}
+	hash := hashing.ComputeHash256(unsignedBytes)
+
+	keysByAddr := make(map[ids.ShortID]S, len(keys))
+	for _, key := range keys {
+		keysByAddr[key.Address()] = key
+	}
+	added := 0
+	for i, credSigners := range signers {
+		cred, ok := tx.Creds[i].(*secp256k1fx.Credential)
+		if !ok || len(cred.Sigs) != len(credSigners) {
+			return 0, fmt.Errorf("%w: credential %d", errUnexpectedCred, i)
+		}
+		for j, signer := range credSigners {
+			key, ok := keysByAddr[signer]
+			if !ok || cred.Sigs[j] != [secp256k1.SignatureLen]byte{} {
+				continue
+			}
+			sig, err := key.SignHash(hash)
+			if err != nil {
+				return 0, err
+			}
+			copy(cred.Sigs[j][:], sig)
+			added++
+		}
+	}
+	return added, tx.Initialize(pTxs.Codec)
+}
+
+// missingPTxSigners returns signers, which signatures tx doesn't have yet
+func missingPTxSigners(tx *pTxs.Tx, signers [][]ids.ShortID) []ids.ShortID {
+	missing := []ids.ShortID{}
+	for i, credSigners := range signers {
+		cred, ok := tx.Creds[i].(*secp256k1fx.Credential)
+		if !ok {
+			continue
+		}
+		for j, signer := range credSigners {
+			if j < len(cred.Sigs) && cred.Sigs[j] == [secp256k1.SignatureLen]byte{} {
+				missing = append(missing, signer)
+			}
+		}
+	}
+	return missing
+}
+
+// newAuthPTxResult creates result of tx signed with signAuthPTx and commits spending,
+// if tx is fully signed. Partially signed txs can't spend utxo pool: their id changes with signatures.
+func (c *Client) newAuthPTxResult(
+	txType string,
+	tx *pTxs.Tx,
+	signers [][]ids.ShortID,
+	spending *pSpending,
+) (*PTxResult, error) {
+	missing := missingPTxSigners(tx, signers)
+	if len(missing) > 0 && spending.pool != nil {
+		c.logger.Error(errPartialTxPool)
+		return nil, errPartialTxPool
+	}
+	result, err := c.newPTxResult(txType, tx, spending.ins, spending.outs)
+	if err != nil {
+		return nil, err
+	}
+	result.signers = signers
+	result.MissingSigners = make([]string, len(missing))
+	for i, signer := range missing {
+		result.MissingSigners[i], err = address.Format("P", c.hrp, signer[:])
+		if err != nil {
+			c.logger.Error(err)
+			return nil, err
+		}
+	}
+	if len(missing) == 0 {
+		spending.commit(tx)
+	}
+	return result, nil
+}
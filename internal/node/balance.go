@@ -0,0 +1,89 @@
+package node
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	pLocked "github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const utxosPageSize = 1024
+
+// PBalance is P-Chain balance of address, summed from its utxos
+type PBalance struct {
+	Total    uint64 `json:"total"`
+	Unlocked uint64 `json:"unlocked"`
+	UTXOs    int    `json:"utxos"`
+}
+
+func (c *Client) GetPBalance(ctx context.Context, addr ids.ShortID) (*PBalance, error) {
+	utxos, err := c.getPUTXOs(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	balance := &PBalance{}
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(avax.TransferableOut)
+		if !ok || utxo.AssetID() != c.avaxAssetID {
+			continue
+		}
+		balance.Total += out.Amount()
+		if _, locked := utxo.Out.(*pLocked.Out); !locked {
+			balance.Unlocked += out.Amount()
+		}
+		balance.UTXOs++
+	}
+	return balance, nil
+}
+
+// getPUTXOs fetches all P-Chain utxos of address
+func (c *Client) getPUTXOs(ctx context.Context, addr ids.ShortID) ([]*avax.UTXO, error) {
+	var utxos []*avax.UTXO
+	startAddr, startUTXOID := ids.ShortEmpty, ids.Empty
+	for {
+		var (
+			utxosBytes [][]byte
+			endAddr    ids.ShortID
+			endUTXOID  ids.ID
+		)
+		if err := c.client.ReadP(ctx, func(ctx context.Context, p platformvm.Client) error {
+			var err error
+			utxosBytes, endAddr, endUTXOID, err = p.GetUTXOs(ctx, []ids.ShortID{addr}, utxosPageSize, startAddr, startUTXOID)
+			return err
+		}); err != nil {
+			c.logger.Error(err)
+			return nil, err
+		}
+		for _, utxoBytes := range utxosBytes {
+			utxo := &avax.UTXO{}
+			if _, err := pTxs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+				c.logger.Error(err)
+				return nil, err
+			}
+			utxos = append(utxos, utxo)
+		}
+		if len(utxosBytes) < utxosPageSize {
+			return utxos, nil
+		}
+		startAddr, startUTXOID = endAddr, endUTXOID
+	}
+}
+
+func (c *Client) GetCBalance(ctx context.Context, addr common.Address) (*big.Int, error) {
+	var balance *big.Int
+	if err := c.client.ReadEVM(ctx, func(ctx context.Context, eth ethclient.Client) error {
+		var err error
+		balance, err = eth.BalanceAt(ctx, addr, nil)
+		return err
+	}); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	return balance, nil
+}
@@ -1,59 +1,72 @@
 package node
 
 import (
+	"caminoclient/internal/audit"
+	"caminoclient/internal/errkind"
 	"caminoclient/internal/logger"
 	"caminoclient/internal/node_client"
+	"caminoclient/internal/policy"
 	"caminoclient/internal/utils"
 	"context"
-	"errors"
+	"fmt"
+	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ethereum/go-ethereum/common"
 )
 
-func NewClient(nodeURI string, logger logger.Logger) (*Client, error) {
-	client, err := node_client.NewClient(nodeURI, logger)
-	if err != nil {
-		return nil, err
-	}
+var errUnknownChain = errkind.New(errkind.InvalidInput, "unknown chain name")
 
-	nodeCfg, err := client.P.GetConfiguration(context.Background())
+func NewClient(ctx context.Context, cfg node_client.Config, logger logger.Logger) (*Client, error) {
+	client, err := node_client.NewClient(ctx, cfg, logger)
 	if err != nil {
-		logger.Error(err)
 		return nil, err
 	}
 
-	// feeKeyAddrStr, err := address.Format("P", hrp, feeKey.Address().Bytes())
-	// if err != nil {
-	// 	logger.Error(err)
-	// 	return nil, err
-	// }
-	// logger.Infof("feeKey: %s : %s : %s\n\n", feeKey.String(), feeKey.Address().String(), feeKeyAddrStr)
-
-	var pChainID, cChainID, xChainID ids.ID
-	for _, bc := range nodeCfg.Blockchains {
-		switch bc.Name {
-		case "C":
-			cChainID = bc.ID
-		case "P":
-			pChainID = bc.ID
-		case "X":
-			xChainID = bc.ID
-		}
-	}
-	return &Client{
+	c := &Client{
 		client: client,
 		logger: logger,
 		utils:  utils.NewUtils(logger),
 
-		avaxAssetID: nodeCfg.AssetID,
-		pChainID:    pChainID,
-		cChainID:    cChainID,
-		xChainID:    xChainID,
-		networkID:   uint32(nodeCfg.NetworkID),
-		hrp:         constants.GetHRP(uint32(nodeCfg.NetworkID)),
-	}, nil
+		evmNonces:    map[common.Address]*evmNonceAccount{},
+		reservations: map[ids.ID][]*policy.Reservation{},
+	}
+
+	if err := client.ReadP(ctx, func(ctx context.Context, p platformvm.Client) error {
+		nodeCfg, err := p.GetConfiguration(ctx)
+		if err != nil {
+			return err
+		}
+
+		// feeKeyAddrStr, err := address.Format("P", hrp, feeKey.Address().Bytes())
+		// if err != nil {
+		// 	logger.Error(err)
+		// 	return nil, err
+		// }
+		// logger.Infof("feeKey: %s : %s : %s\n\n", feeKey.String(), feeKey.Address().String(), feeKeyAddrStr)
+
+		for _, bc := range nodeCfg.Blockchains {
+			switch bc.Name {
+			case "C":
+				c.cChainID = bc.ID
+			case "P":
+				c.pChainID = bc.ID
+			case "X":
+				c.xChainID = bc.ID
+			}
+		}
+		c.avaxAssetID = nodeCfg.AssetID
+		c.networkID = uint32(nodeCfg.NetworkID)
+		c.hrp = constants.GetHRP(c.networkID)
+		return nil
+	}); err != nil {
+		logger.Error(err)
+		return nil, err
+	}
+	return c, nil
 }
 
 type Client struct {
@@ -67,12 +80,34 @@ type Client struct {
 	xChainID    ids.ID
 	networkID   uint32
 	hrp         string
+
+	evmNoncesLock sync.Mutex
+	evmNonces     map[common.Address]*evmNonceAccount
+
+	policy *policy.Engine // nil allows any signing
+	audit  *audit.Log     // nil disables audit
+
+	reservationsLock sync.Mutex
+	reservations     map[ids.ID][]*policy.Reservation // policy reservations of signed txs by hash of unsigned tx
 }
 
-func (c *Client) GetPTX(txID ids.ID) (*txs.Tx, error) {
+func (c *Client) NetworkID() uint32 {
+	return c.networkID
+}
+
+// HRP returns human-readable part of bech32 addresses for network the client is connected to
+func (c *Client) HRP() string {
+	return c.hrp
+}
+
+func (c *Client) GetPTX(ctx context.Context, txID ids.ID) (*txs.Tx, error) {
 	c.logger.Info("Getting P-Chain tx...")
-	txBytes, err := c.client.P.GetTx(context.Background(), txID)
-	if err != nil {
+	var txBytes []byte
+	if err := c.client.ReadP(ctx, func(ctx context.Context, p platformvm.Client) error {
+		var err error
+		txBytes, err = p.GetTx(ctx, txID)
+		return err
+	}); err != nil {
 		c.logger.Error(err)
 		return nil, err
 	}
@@ -88,5 +123,5 @@ func (c *Client) getChainID(chainName string) (ids.ID, error) {
 	case "X":
 		return c.xChainID, nil
 	}
-	return ids.Empty, errors.New("unknown chain name")
+	return ids.Empty, fmt.Errorf("%w: %s", errUnknownChain, chainName)
 }
@@ -0,0 +1,232 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	errNoBytecode           = errkind.New(errkind.InvalidInput, "artifact has no bytecode")
+	errContractNotFound     = errkind.New(errkind.InvalidInput, "contract not found in artifact")
+	errAmbiguousContract    = errkind.New(errkind.InvalidInput, "artifact contains several contracts, contract name must be specified")
+	errUnlinkedBytecode     = errkind.New(errkind.InvalidInput, "bytecode has unlinked libraries")
+	errContractDeployFailed = errkind.New(errkind.TxRejected, "contract deployment tx failed")
+)
+
+// ContractArtifact is compiled contract abi and creation bytecode
+type ContractArtifact struct {
+	Name     string
+	ABI      abi.ABI
+	Bytecode []byte
+}
+
+// ContractDeployment is result of contract deployment
+type ContractDeployment struct {
+	Name     string         `json:"name"`
+	Address  common.Address `json:"address"`
+	TxHash   common.Hash    `json:"txHash"`
+	Deployer common.Address `json:"deployer"`
+	Receipt  *types.Receipt `json:"receipt"`
+}
+
+// artifactJSON covers solc (--combined-json and standard json output contract),
+// Hardhat and Foundry artifact formats
+type artifactJSON struct {
+	ContractName string          `json:"contractName"`
+	ABI          json.RawMessage `json:"abi"`
+	Bin          string          `json:"bin"`
+	Bytecode     json.RawMessage `json:"bytecode"` // hardhat: "0x...", foundry: {"object": "0x..."}
+	EVM          *struct {
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
+	} `json:"evm"`
+	Contracts map[string]*artifactJSON `json:"contracts"`
+}
+
+// ReadContractArtifact reads contract artifact from solc, Hardhat or Foundry json file.
+// If artifact contains several contracts (solc --combined-json), contractName must be provided.
+func ReadContractArtifact(path, contractName string) (*ContractArtifact, error) {
+	name, artifact, contractABI, err := readArtifact(path, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	bytecodeStr := artifact.Bin
+	if artifact.EVM != nil {
+		bytecodeStr = artifact.EVM.Bytecode.Object
+	}
+	if len(artifact.Bytecode) > 0 {
+		bytecodeObject := struct {
+			Object string `json:"object"`
+		}{}
+		if err := json.Unmarshal(artifact.Bytecode, &bytecodeStr); err != nil {
+			if err := json.Unmarshal(artifact.Bytecode, &bytecodeObject); err != nil {
+				return nil, err
+			}
+			bytecodeStr = bytecodeObject.Object
+		}
+	}
+	if strings.Contains(bytecodeStr, "__") {
+		return nil, errUnlinkedBytecode
+	}
+	if !strings.HasPrefix(bytecodeStr, "0x") {
+		bytecodeStr = "0x" + bytecodeStr
+	}
+	bytecode, err := hexutil.Decode(bytecodeStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytecode) == 0 {
+		return nil, errNoBytecode
+	}
+
+	return &ContractArtifact{
+		Name:     name,
+		ABI:      *contractABI,
+		Bytecode: bytecode,
+	}, nil
+}
+
+// ReadContractABI reads contract abi either from plain abi json file
+// or from solc, Hardhat or Foundry artifact.
+func ReadContractABI(path, contractName string) (*abi.ABI, error) {
+	abiBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(abiBytes); len(trimmed) > 0 && trimmed[0] == '[' {
+		contractABI, err := abi.JSON(bytes.NewReader(trimmed))
+		if err != nil {
+			return nil, err
+		}
+		return &contractABI, nil
+	}
+	_, _, contractABI, err := readArtifact(path, contractName)
+	return contractABI, err
+}
+
+func readArtifact(path, contractName string) (string, *artifactJSON, *abi.ABI, error) {
+	artifactBytes, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	artifact := &artifactJSON{}
+	if err := json.Unmarshal(artifactBytes, artifact); err != nil {
+		return "", nil, nil, err
+	}
+
+	name := artifact.ContractName
+	if len(artifact.Contracts) > 0 {
+		name, artifact, err = selectArtifactContract(artifact.Contracts, contractName)
+		if err != nil {
+			return "", nil, nil, err
+		}
+	}
+	if name == "" {
+		name = contractName
+	}
+
+	abiJSON := artifact.ABI
+	// solc --combined-json of older versions has abi as json string
+	abiStr := ""
+	if err := json.Unmarshal(abiJSON, &abiStr); err == nil {
+		abiJSON = json.RawMessage(abiStr)
+	}
+	contractABI, err := abi.JSON(bytes.NewReader(abiJSON))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return name, artifact, &contractABI, nil
+}
+
+// selectArtifactContract selects contract by name from "path:Name" keyed contracts map
+func selectArtifactContract(contracts map[string]*artifactJSON, contractName string) (string, *artifactJSON, error) {
+	if contractName == "" {
+		if len(contracts) > 1 {
+			return "", nil, errAmbiguousContract
+		}
+		for key, contract := range contracts {
+			return key[strings.LastIndex(key, ":")+1:], contract, nil
+		}
+	}
+	for key, contract := range contracts {
+		if key == contractName || strings.HasSuffix(key, ":"+contractName) {
+			return contractName, contract, nil
+		}
+	}
+	return "", nil, fmt.Errorf("%w: %s", errContractNotFound, contractName)
+}
+
+// DeployContract sends contract creation tx with abi-encoded constructor args and waits for its receipt
+func (c *Client) DeployContract(
+	ctx context.Context,
+	artifact *ContractArtifact,
+	constructorArgs []interface{},
+	key Signer,
+) (*ContractDeployment, error) {
+	c.logger.Infof("Creating C-Chain %s contract deployment tx...", artifact.Name)
+	data, err := deploymentData(artifact, constructorArgs)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	tx, err := c.SendEVMTx(ctx, key, nil, nil, data)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := c.WaitForEVMReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		err := fmt.Errorf("%w: %s", errContractDeployFailed, tx.Hash())
+		c.logger.Error(err)
+		return nil, err
+	}
+	c.logger.Infof("contract %s deployed at %s", artifact.Name, receipt.ContractAddress)
+
+	return &ContractDeployment{
+		Name:     artifact.Name,
+		Address:  receipt.ContractAddress,
+		TxHash:   tx.Hash(),
+		Deployer: EVMAddress(key),
+		Receipt:  receipt,
+	}, nil
+}
+
+// EstimateContractDeploy estimates gas and max fee of contract creation tx from given address
+func (c *Client) EstimateContractDeploy(
+	ctx context.Context,
+	artifact *ContractArtifact,
+	constructorArgs []interface{},
+	from common.Address,
+) (*EVMTxEstimate, error) {
+	data, err := deploymentData(artifact, constructorArgs)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	return c.EstimateEVMTx(ctx, from, nil, nil, data)
+}
+
+// deploymentData returns creation bytecode followed by abi-encoded constructor args
+func deploymentData(artifact *ContractArtifact, constructorArgs []interface{}) ([]byte, error) {
+	encodedArgs, err := artifact.ABI.Pack("", constructorArgs...)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, artifact.Bytecode...), encodedArgs...), nil
+}
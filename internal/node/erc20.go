@@ -0,0 +1,200 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const erc20ABIJSON = `[
+	{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+	{"type":"function","name":"totalSupply","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"allowance","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"approve","stateMutability":"nonpayable","inputs":[{"name":"spender","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Approval","anonymous":false,"inputs":[{"name":"owner","type":"address","indexed":true},{"name":"spender","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+var erc20ABI = func() abi.ABI {
+	parsedABI, err := abi.JSON(strings.NewReader(erc20ABIJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsedABI
+}()
+
+type ERC20Info struct {
+	Address     common.Address `json:"address"`
+	Name        string         `json:"name"`
+	Symbol      string         `json:"symbol"`
+	Decimals    uint8          `json:"decimals"`
+	TotalSupply *big.Int       `json:"totalSupply"`
+}
+
+// ERC20TxResult is receipt of erc20 tx with decoded Transfer/Approval events
+type ERC20TxResult struct {
+	Receipt *types.Receipt  `json:"receipt"`
+	Events  []*DecodedEvent `json:"events"`
+}
+
+func (c *Client) ERC20Info(ctx context.Context, token common.Address) (*ERC20Info, error) {
+	info := &ERC20Info{Address: token}
+	if err := c.callERC20(ctx, token, &info.Name, "name"); err != nil {
+		return nil, err
+	}
+	if err := c.callERC20(ctx, token, &info.Symbol, "symbol"); err != nil {
+		return nil, err
+	}
+	if err := c.callERC20(ctx, token, &info.Decimals, "decimals"); err != nil {
+		return nil, err
+	}
+	if err := c.callERC20(ctx, token, &info.TotalSupply, "totalSupply"); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (c *Client) ERC20Decimals(ctx context.Context, token common.Address) (uint8, error) {
+	var decimals uint8
+	if err := c.callERC20(ctx, token, &decimals, "decimals"); err != nil {
+		return 0, err
+	}
+	return decimals, nil
+}
+
+func (c *Client) ERC20Balance(ctx context.Context, token, owner common.Address) (*big.Int, error) {
+	var balance *big.Int
+	if err := c.callERC20(ctx, token, &balance, "balanceOf", owner); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+func (c *Client) ERC20Allowance(ctx context.Context, token, owner, spender common.Address) (*big.Int, error) {
+	var allowance *big.Int
+	if err := c.callERC20(ctx, token, &allowance, "allowance", owner, spender); err != nil {
+		return nil, err
+	}
+	return allowance, nil
+}
+
+// ERC20Transfer sends erc20 transfer tx without waiting for its receipt
+func (c *Client) ERC20Transfer(
+	ctx context.Context,
+	token, to common.Address,
+	amount *big.Int,
+	key Signer,
+) (*types.Transaction, error) {
+	c.logger.Info("Creating C-Chain erc20 transfer tx...")
+	return c.sendERC20Tx(ctx, token, key, "transfer", to, amount)
+}
+
+// ERC20Approve sends erc20 approve tx without waiting for its receipt
+func (c *Client) ERC20Approve(
+	ctx context.Context,
+	token, spender common.Address,
+	amount *big.Int,
+	key Signer,
+) (*types.Transaction, error) {
+	c.logger.Info("Creating C-Chain erc20 approve tx...")
+	return c.sendERC20Tx(ctx, token, key, "approve", spender, amount)
+}
+
+// EstimateERC20Transfer estimates gas and max fee of erc20 transfer tx from given address
+func (c *Client) EstimateERC20Transfer(
+	ctx context.Context,
+	token, from, to common.Address,
+	amount *big.Int,
+) (*EVMTxEstimate, error) {
+	return c.estimateERC20Tx(ctx, token, from, "transfer", to, amount)
+}
+
+// EstimateERC20Approve estimates gas and max fee of erc20 approve tx from given address
+func (c *Client) EstimateERC20Approve(
+	ctx context.Context,
+	token, owner, spender common.Address,
+	amount *big.Int,
+) (*EVMTxEstimate, error) {
+	return c.estimateERC20Tx(ctx, token, owner, "approve", spender, amount)
+}
+
+// WaitForERC20TxResult waits for erc20 tx receipt and decodes events emitted by token
+func (c *Client) WaitForERC20TxResult(ctx context.Context, token common.Address, txHash common.Hash) (*ERC20TxResult, error) {
+	receipt, err := c.WaitForEVMReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	events, err := decodeEVMLogs(&erc20ABI, token, receipt.Logs)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	return &ERC20TxResult{Receipt: receipt, Events: events}, nil
+}
+
+func (c *Client) sendERC20Tx(
+	ctx context.Context,
+	token common.Address,
+	key Signer,
+	method string,
+	args ...interface{},
+) (*types.Transaction, error) {
+	data, err := erc20ABI.Pack(method, args...)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	return c.SendEVMTx(ctx, key, &token, nil, data)
+}
+
+func (c *Client) estimateERC20Tx(
+	ctx context.Context,
+	token, from common.Address,
+	method string,
+	args ...interface{},
+) (*EVMTxEstimate, error) {
+	data, err := erc20ABI.Pack(method, args...)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	return c.EstimateEVMTx(ctx, from, &token, nil, data)
+}
+
+func (c *Client) callERC20(ctx context.Context, token common.Address, result interface{}, method string, args ...interface{}) error {
+	data, err := erc20ABI.Pack(method, args...)
+	if err != nil {
+		c.logger.Error(err)
+		return err
+	}
+	var resultBytes []byte
+	if err := c.client.ReadEVM(ctx, func(ctx context.Context, eth ethclient.Client) error {
+		var err error
+		resultBytes, err = eth.CallContract(ctx, interfaces.CallMsg{To: &token, Data: data}, nil)
+		return err
+	}); err != nil {
+		c.logger.Error(err)
+		return err
+	}
+	if len(resultBytes) == 0 {
+		err := fmt.Errorf("%s has no code or doesn't implement %s", token, method)
+		c.logger.Error(err)
+		return err
+	}
+	if err := erc20ABI.UnpackIntoInterface(result, method, resultBytes); err != nil {
+		c.logger.Error(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"caminoclient/internal/audit"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const evmReceiptPollInterval = time.Second
+
+var ErrUnknownEvent = errors.New("unknown event")
+
+// DecodedEvent is evm log decoded with contract abi
+type DecodedEvent struct {
+	Address     common.Address         `json:"address"`
+	Name        string                 `json:"event"`
+	TxHash      common.Hash            `json:"txHash"`
+	BlockNumber uint64                 `json:"blockNumber"`
+	LogIndex    uint                   `json:"logIndex"`
+	Fields      map[string]interface{} `json:"fields"`
+}
+
+// EVMTxEstimate is gas, that C-Chain tx is expected to use, and max fee paid for it
+type EVMTxEstimate struct {
+	Gas    uint64   `json:"gas"`
+	MaxFee *big.Int `json:"maxFee"` // wei
+}
+
+type evmTxParams struct {
+	chainID   *big.Int
+	gas       uint64
+	gasTipCap *big.Int
+	gasFeeCap *big.Int
+}
+
+// SendEVMTx creates, signs and sends C-Chain tx with dynamic fee.
+// Nonces are tracked locally, so several consecutive sends from the same key
+// don't have to wait for previous txs acceptance.
+func (c *Client) SendEVMTx(
+	ctx context.Context,
+	key Signer,
+	to *common.Address,
+	value *big.Int,
+	data []byte,
+) (*types.Transaction, error) {
+	from := EVMAddress(key)
+	nonce, err := c.ReserveEVMNonces(ctx, from, 1)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.SendEVMTxWithNonce(ctx, key, nonce, to, value, data)
+	if err != nil {
+		c.ReleaseEVMNonce(from, nonce)
+		return nil, err
+	}
+	return tx, nil
+}
+
+// SendEVMTxWithNonce creates, signs and sends C-Chain tx with dynamic fee and given nonce,
+// which must be reserved with ReserveEVMNonces.
+func (c *Client) SendEVMTxWithNonce(
+	ctx context.Context,
+	key Signer,
+	nonce uint64,
+	to *common.Address,
+	value *big.Int,
+	data []byte,
+) (*types.Transaction, error) {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	from := EVMAddress(key)
+	params, err := c.evmTxParams(ctx, from, to, value, data)
+	if err != nil {
+		return nil, err
+	}
+
+	req := c.evmSigningRequest(key, to, value, data)
+	reservation, err := c.authorize(req)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	signed := false
+	defer func() {
+		if !signed {
+			reservation.Release()
+		}
+	}()
+	ethSigner := types.LatestSignerForChainID(params.chainID)
+	unsignedTx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   params.chainID,
+		Nonce:     nonce,
+		GasTipCap: params.gasTipCap,
+		GasFeeCap: params.gasFeeCap,
+		Gas:       params.gas,
+		To:        to,
+		Value:     value,
+		Data:      data,
+	})
+	sig, err := key.SignHash(ethSigner.Hash(unsignedTx).Bytes())
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	tx, err := unsignedTx.WithSignature(ethSigner, sig)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	txID := ids.ID(tx.Hash())
+	if err := c.auditTx(audit.EventSigned, req, txID, txBytes); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	signed = true
+	c.holdReservation(txID[:], reservation)
+
+	if err := c.issueAudited(txBytes,
+		func() (*issuedTx, error) { return &issuedTx{req: req, id: txID, unsignedBytes: txID[:]}, nil },
+		func() error { return c.client.SendEVMTx(ctx, tx) },
+	); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	c.markEVMNonceSent(from, nonce)
+	c.logger.Infof("txID: %s (nonce %d)", tx.Hash(), nonce)
+	return tx, nil
+}
+
+// EstimateEVMTx returns gas, that C-Chain tx from given address is expected to use,
+// and max fee, that would be paid for it
+func (c *Client) EstimateEVMTx(
+	ctx context.Context,
+	from common.Address,
+	to *common.Address,
+	value *big.Int,
+	data []byte,
+) (*EVMTxEstimate, error) {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	params, err := c.evmTxParams(ctx, from, to, value, data)
+	if err != nil {
+		return nil, err
+	}
+	return &EVMTxEstimate{
+		Gas:    params.gas,
+		MaxFee: new(big.Int).Mul(params.gasFeeCap, new(big.Int).SetUint64(params.gas)),
+	}, nil
+}
+
+// evmTxParams fetches chain id, estimates gas limit and dynamic fee caps of tx
+func (c *Client) evmTxParams(
+	ctx context.Context,
+	from common.Address,
+	to *common.Address,
+	value *big.Int,
+	data []byte,
+) (*evmTxParams, error) {
+	params := &evmTxParams{}
+	var baseFee *big.Int
+	if err := c.client.ReadEVM(ctx, func(ctx context.Context, eth ethclient.Client) error {
+		var err error
+		if params.chainID, err = eth.ChainID(ctx); err != nil {
+			return err
+		}
+		if params.gas, err = eth.EstimateGas(ctx, interfaces.CallMsg{
+			From:  from,
+			To:    to,
+			Value: value,
+			Data:  data,
+		}); err != nil {
+			return err
+		}
+		if params.gasTipCap, err = eth.SuggestGasTipCap(ctx); err != nil {
+			return err
+		}
+		baseFee, err = eth.EstimateBaseFee(ctx)
+		return err
+	}); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	params.gasFeeCap = new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), params.gasTipCap)
+	return params, nil
+}
+
+// WaitForEVMReceipt polls node until receipt of tx with given hash is available
+func (c *Client) WaitForEVMReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.logger.Infof("Waiting for C-Chain tx %s receipt...", txHash)
+	ticker := time.NewTicker(evmReceiptPollInterval)
+	defer ticker.Stop()
+	for {
+		var receipt *types.Receipt
+		err := c.client.ReadEVM(ctx, func(ctx context.Context, eth ethclient.Client) error {
+			var err error
+			receipt, err = eth.TransactionReceipt(ctx, txHash)
+			return err
+		})
+		switch {
+		case err == nil:
+			return receipt, nil
+		case !errors.Is(err, interfaces.NotFound):
+			c.logger.Error(err)
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			c.logger.Error(ctx.Err())
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// decodeEVMLogs decodes logs emitted by contract with given abi,
+// logs of other contracts or with unknown events are skipped
+func decodeEVMLogs(contractABI *abi.ABI, contractAddr common.Address, logs []*types.Log) ([]*DecodedEvent, error) {
+	events := make([]*DecodedEvent, 0, len(logs))
+	for _, log := range logs {
+		if log.Address != contractAddr {
+			continue
+		}
+		event, err := DecodeEVMLog(contractABI, log)
+		if errors.Is(err, ErrUnknownEvent) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// DecodeEVMLog decodes log into event with named fields,
+// returns ErrUnknownEvent if abi has no event matching log topic
+func DecodeEVMLog(contractABI *abi.ABI, log *types.Log) (*DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, ErrUnknownEvent
+	}
+	event, err := contractABI.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, ErrUnknownEvent
+	}
+
+	fields := map[string]interface{}{}
+	if err := event.Inputs.UnpackIntoMap(fields, log.Data); err != nil {
+		return nil, err
+	}
+	indexed := abi.Arguments{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(fields, indexed, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+
+	return &DecodedEvent{
+		Address:     log.Address,
+		Name:        event.Name,
+		TxHash:      log.TxHash,
+		BlockNumber: log.BlockNumber,
+		LogIndex:    log.Index,
+		Fields:      fields,
+	}, nil
+}
@@ -0,0 +1,204 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	errNoFundsKeys          = errkind.New(errkind.InvalidInput, "no funds keys")
+	errInsufficientCBalance = errkind.New(errkind.InsufficientFunds, "insufficient C-Chain balance")
+)
+
+// EVMExportTx creates C-Chain ExportTx, that exports avax to recipients on targetChain.
+// Recipients can be multisig owners with threshold and locktime, e.g. owners of msig alias.
+// Funds are taken from fundsKeys evm addresses in given order, every used address is tx input,
+// fee is recalculated for every added input and its signature.
+func (c *Client) EVMExportTx(
+	ctx context.Context,
+	recipients []*TransferRecipient,
+	fundsKeys []Signer,
+	targetChain string,
+) (*CTxResult, error) {
+	outs, amount, err := c.transferOutputs(recipients)
+	if err != nil {
+		return nil, err
+	}
+	return c.evmExportTx(ctx, outs, amount, fundsKeys, targetChain)
+}
+
+// evmExportTx creates C-Chain ExportTx with given outputs, which avax amounts sum is amountToExport
+func (c *Client) evmExportTx(
+	ctx context.Context,
+	outs []*avax.TransferableOutput,
+	amountToExport uint64,
+	fundsKeys []Signer,
+	targetChain string,
+) (*CTxResult, error) {
+	c.logger.Info("Creating C-Chain exportTx...")
+
+	destinationChainID, err := c.getChainID(targetChain)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	// evm inputs must be unique
+	keys := make([]Signer, 0, len(fundsKeys))
+	keysByAddr := make(map[common.Address]Signer, len(fundsKeys))
+	for _, key := range fundsKeys {
+		addr := EVMAddress(key)
+		if _, ok := keysByAddr[addr]; ok {
+			continue
+		}
+		keysByAddr[addr] = key
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		c.logger.Error(errNoFundsKeys)
+		return nil, errNoFundsKeys
+	}
+	avax.SortTransferableOutputs(outs, evm.Codec)
+
+	// calculate fee: gas of tx without inputs and gas of every input with its signature
+
+	tx := &evm.Tx{UnsignedAtomicTx: &evm.UnsignedExportTx{
+		NetworkID:        c.networkID,
+		BlockchainID:     c.cChainID,
+		DestinationChain: destinationChainID,
+		ExportedOutputs:  outs,
+	}}
+	if err := tx.Sign(evm.Codec, nil); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	txGasUsed, err := tx.GasUsed(true)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	var baseFee *big.Int
+	if err := c.client.ReadEVM(ctx, func(ctx context.Context, eth ethclient.Client) error {
+		var err error
+		baseFee, err = eth.EstimateBaseFee(ctx)
+		return err
+	}); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	feeWithInputs := func(inputsCount int) (uint64, error) {
+		inputsGas, err := math.Mul64(uint64(inputsCount), EVMInputGas)
+		if err != nil {
+			return 0, err
+		}
+		gasUsed, err := math.Add64(txGasUsed, inputsGas)
+		if err != nil {
+			return 0, err
+		}
+		return calculateEVMDynamicFee(gasUsed, baseFee)
+	}
+
+	// select inputs
+
+	ins := []evm.EVMInput{}
+	consumed, amountToConsume := uint64(0), uint64(0)
+	for _, key := range keys {
+		fee, err := feeWithInputs(len(ins) + 1)
+		if err != nil {
+			c.logger.Error(err)
+			return nil, err
+		}
+		amountToConsume, err = math.Add64(amountToExport, fee)
+		if err != nil {
+			c.logger.Error(err)
+			return nil, err
+		}
+
+		addr := EVMAddress(key)
+		balance, err := c.GetCBalance(ctx, addr)
+		if err != nil {
+			return nil, err
+		}
+		balance = new(big.Int).Div(balance, x2cRate) // wei -> nCAM
+		if balance.Sign() == 0 {
+			continue
+		}
+
+		amount := amountToConsume - consumed
+		if balance.IsUint64() && balance.Uint64() < amount {
+			amount = balance.Uint64()
+		}
+		ins = append(ins, evm.EVMInput{
+			Address: addr,
+			Amount:  amount,
+			AssetID: c.avaxAssetID,
+		})
+		consumed += amount
+		if consumed == amountToConsume {
+			break
+		}
+	}
+	if len(ins) == 0 || consumed < amountToConsume {
+		err := fmt.Errorf("%w: needs %d, %d addresses have %d", errInsufficientCBalance, amountToConsume, len(keys), consumed)
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	// nonces are reserved until tx is issued or considered dropped
+	reserved := []evm.EVMInput{}
+	built := false
+	defer func() {
+		if !built {
+			for _, in := range reserved {
+				c.ReleaseEVMNonce(in.Address, in.Nonce)
+			}
+		}
+	}()
+	for i := range ins {
+		ins[i].Nonce, err = c.ReserveEVMNonces(ctx, ins[i].Address, 1)
+		if err != nil {
+			return nil, err
+		}
+		reserved = append(reserved, ins[i])
+	}
+
+	// create tx
+
+	// inputs are sorted by address, as evm requires, their addresses are unique
+	sort.Slice(ins, func(i, j int) bool {
+		return bytes.Compare(ins[i].Address[:], ins[j].Address[:]) < 0
+	})
+	signers := make([][]Signer, len(ins))
+	for i, in := range ins {
+		signers[i] = []Signer{keysByAddr[in.Address]}
+	}
+	utx := &evm.UnsignedExportTx{
+		NetworkID:        c.networkID,
+		BlockchainID:     c.cChainID,
+		DestinationChain: destinationChainID,
+		Ins:              ins,
+		ExportedOutputs:  outs,
+	}
+	tx = &evm.Tx{UnsignedAtomicTx: utx}
+	if err := c.signCTx(tx, signers); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	result, err := c.newCTxResult("ExportTx", tx, utx.Ins, targetChain, outs)
+	built = err == nil
+	return result, err
+}
@@ -0,0 +1,261 @@
+package node
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// nonce of sent tx that is still not known to node mempool after this timeout
+// is considered dropped and is given out again
+const evmNonceDropTimeout = 2 * time.Minute
+
+var errZeroNonceCount = errkind.New(errkind.InvalidInput, "nonce count must be positive")
+
+// EVMNonceState is nonce state of C-Chain address
+type EVMNonceState struct {
+	Address  common.Address `json:"address"`
+	Accepted uint64         `json:"accepted"` // nonce of next tx that will be accepted
+	Pool     uint64         `json:"pool"`     // next nonce according to node mempool
+	Next     uint64         `json:"next"`     // next nonce that will be reserved by this client
+	Pending  []uint64       `json:"pending"`  // nonces reserved by this client, but not accepted yet
+}
+
+// evmNonceAccount is nonce state of C-Chain address tracked by this client
+type evmNonceAccount struct {
+	next     uint64               // next nonce to reserve
+	pending  map[uint64]time.Time // reserved, but not accepted nonces with time their tx was sent, zero if it wasn't sent yet
+	released map[uint64]struct{}  // reserved, but unused nonces below next
+}
+
+func newEVMNonceAccount() *evmNonceAccount {
+	return &evmNonceAccount{
+		pending:  map[uint64]time.Time{},
+		released: map[uint64]struct{}{},
+	}
+}
+
+// ReserveEVMNonces reserves count consecutive nonces for address and returns the first one.
+// Nonces of txs that were sent by this client, but not yet accepted, are skipped.
+// Reserved nonce must be released with ReleaseEVMNonce, if tx with it wasn't sent.
+// Reserved nonce is held until its tx is sent, it's never considered dropped before that.
+func (c *Client) ReserveEVMNonces(ctx context.Context, addr common.Address, count uint64) (uint64, error) {
+	if count == 0 {
+		c.logger.Error(errZeroNonceCount)
+		return 0, errZeroNonceCount
+	}
+
+	acceptedNonce, poolNonce, err := c.fetchEVMNonces(ctx, addr)
+	if err != nil {
+		c.logger.Error(err)
+		return 0, err
+	}
+
+	c.evmNoncesLock.Lock()
+	defer c.evmNoncesLock.Unlock()
+
+	return c.syncEVMNonces(addr, acceptedNonce, poolNonce).reserve(count), nil
+}
+
+// reserve reserves count consecutive nonces and returns the first one,
+// single nonce is reserved from released ones, if there are any
+func (a *evmNonceAccount) reserve(count uint64) uint64 {
+	if count == 1 && len(a.released) > 0 {
+		nonce := lowestNonce(a.released)
+		delete(a.released, nonce)
+		a.pending[nonce] = time.Time{}
+		return nonce
+	}
+
+	first := a.next
+	for nonce := first; nonce < first+count; nonce++ {
+		a.pending[nonce] = time.Time{}
+	}
+	a.next += count
+	return first
+}
+
+// ReleaseEVMNonce gives back reserved nonce that wasn't used,
+// so it will be reserved again by next tx
+func (c *Client) ReleaseEVMNonce(addr common.Address, nonce uint64) {
+	c.evmNoncesLock.Lock()
+	defer c.evmNoncesLock.Unlock()
+
+	account, ok := c.evmNonces[addr]
+	if !ok {
+		return
+	}
+	if _, ok := account.pending[nonce]; !ok {
+		return
+	}
+	delete(account.pending, nonce)
+	account.released[nonce] = struct{}{}
+	account.trimReleased()
+}
+
+// markEVMNonceSent records that tx with reserved nonce was sent to node,
+// so its nonce can be considered dropped, if node mempool loses the tx
+func (c *Client) markEVMNonceSent(addr common.Address, nonce uint64) {
+	c.evmNoncesLock.Lock()
+	defer c.evmNoncesLock.Unlock()
+
+	account, ok := c.evmNonces[addr]
+	if !ok {
+		return
+	}
+	if _, ok := account.pending[nonce]; ok {
+		account.pending[nonce] = time.Now()
+	}
+}
+
+// trimReleased drops trailing released nonces, they are just not reserved ones
+func (a *evmNonceAccount) trimReleased() {
+	for a.next > 0 {
+		if _, ok := a.released[a.next-1]; !ok {
+			break
+		}
+		delete(a.released, a.next-1)
+		a.next--
+	}
+}
+
+// ResetEVMNonces drops nonce state of address tracked by this client,
+// so next reservation will rely only on node state
+func (c *Client) ResetEVMNonces(addr common.Address) {
+	c.evmNoncesLock.Lock()
+	defer c.evmNoncesLock.Unlock()
+	delete(c.evmNonces, addr)
+}
+
+// GetEVMNonceState returns nonce state of address according to node and this client
+func (c *Client) GetEVMNonceState(ctx context.Context, addr common.Address) (*EVMNonceState, error) {
+	acceptedNonce, poolNonce, err := c.fetchEVMNonces(ctx, addr)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	c.evmNoncesLock.Lock()
+	defer c.evmNoncesLock.Unlock()
+
+	account := c.syncEVMNonces(addr, acceptedNonce, poolNonce)
+	state := &EVMNonceState{
+		Address:  addr,
+		Accepted: acceptedNonce,
+		Pool:     poolNonce,
+		Next:     account.next,
+		Pending:  make([]uint64, 0, len(account.pending)),
+	}
+	if len(account.released) > 0 {
+		state.Next = lowestNonce(account.released)
+	}
+	for nonce := range account.pending {
+		state.Pending = append(state.Pending, nonce)
+	}
+	sort.Slice(state.Pending, func(i, j int) bool { return state.Pending[i] < state.Pending[j] })
+	return state, nil
+}
+
+// fetchEVMNonces returns node accepted nonce of address and next nonce according to node mempool.
+// It's called without evmNoncesLock, so retried rpc calls don't block nonce reservations of other txs.
+func (c *Client) fetchEVMNonces(ctx context.Context, addr common.Address) (uint64, uint64, error) {
+	acceptedNonce, poolNonce := uint64(0), hexutil.Uint64(0)
+	if err := c.client.ReadEVM(ctx, func(ctx context.Context, eth ethclient.Client) error {
+		var err error
+		acceptedNonce, err = eth.AcceptedNonceAt(ctx, addr)
+		return err
+	}); err != nil {
+		return 0, 0, err
+	}
+	// ethclient maps negative block numbers to "latest" tag, which is accepted state,
+	// so mempool nonce is requested with "pending" tag directly
+	if err := c.client.ReadEVMRPC(ctx, func(ctx context.Context, rpcClient *rpc.Client) error {
+		return rpcClient.CallContext(ctx, &poolNonce, "eth_getTransactionCount", addr, "pending")
+	}); err != nil {
+		return 0, 0, err
+	}
+	if uint64(poolNonce) < acceptedNonce {
+		return acceptedNonce, acceptedNonce, nil
+	}
+	return acceptedNonce, uint64(poolNonce), nil
+}
+
+// syncEVMNonces updates tracked nonce state of address with node accepted and mempool nonces.
+// Must be called under evmNoncesLock.
+func (c *Client) syncEVMNonces(addr common.Address, acceptedNonce, poolNonce uint64) *evmNonceAccount {
+	account, ok := c.evmNonces[addr]
+	if !ok {
+		account = newEVMNonceAccount()
+		c.evmNonces[addr] = account
+	}
+
+	for nonce := range account.pending {
+		if nonce < acceptedNonce {
+			delete(account.pending, nonce)
+		}
+	}
+	// released nonces below mempool nonce were used by someone else
+	for nonce := range account.released {
+		if nonce < poolNonce {
+			delete(account.released, nonce)
+		}
+	}
+	if account.next < acceptedNonce {
+		account.next = acceptedNonce
+	}
+
+	// if tx with first nonce missing in mempool was sent long ago, it and all txs after it
+	// were dropped by node. Their nonces are reused, except ones that are still held
+	// by not sent txs.
+	if poolNonce < account.next && account.isDropped(poolNonce) {
+		c.logger.Infof("txs of %s with nonces %d-%d were dropped, their nonces will be reused", addr, poolNonce, account.next-1)
+		account.releaseDropped(poolNonce)
+	}
+
+	// txs could be sent by someone else
+	if account.next < poolNonce {
+		account.next = poolNonce
+	}
+	return account
+}
+
+// isDropped reports, if tx with nonce, which node mempool doesn't know, was dropped.
+// Nonce, that isn't tracked, was used by someone else.
+func (a *evmNonceAccount) isDropped(nonce uint64) bool {
+	if _, ok := a.released[nonce]; ok {
+		return false
+	}
+	sentAt, ok := a.pending[nonce]
+	return !ok || (!sentAt.IsZero() && time.Since(sentAt) > evmNonceDropTimeout)
+}
+
+// releaseDropped releases nonces from first dropped one, that aren't held by not sent txs
+func (a *evmNonceAccount) releaseDropped(firstDropped uint64) {
+	for nonce := firstDropped; nonce < a.next; nonce++ {
+		if sentAt, ok := a.pending[nonce]; ok && sentAt.IsZero() {
+			continue
+		}
+		delete(a.pending, nonce)
+		a.released[nonce] = struct{}{}
+	}
+	a.trimReleased()
+}
+
+func lowestNonce(nonces map[uint64]struct{}) uint64 {
+	first := true
+	lowest := uint64(0)
+	for nonce := range nonces {
+		if first || nonce < lowest {
+			lowest = nonce
+			first = false
+		}
+	}
+	return lowest
+}
@@ -0,0 +1,130 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"caminoclient/internal/logger"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var testEVMAddr = common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+
+func newTestNonceClient() *Client {
+	return &Client{
+		logger:    &logger.NoLog,
+		evmNonces: map[common.Address]*evmNonceAccount{},
+	}
+}
+
+// testReserve reserves count nonces of test address with given node nonces
+func (c *Client) testReserve(acceptedNonce, poolNonce, count uint64) uint64 {
+	c.evmNoncesLock.Lock()
+	defer c.evmNoncesLock.Unlock()
+	return c.syncEVMNonces(testEVMAddr, acceptedNonce, poolNonce).reserve(count)
+}
+
+// testSentAt marks reserved nonce of test address as sent at given time
+func (c *Client) testSentAt(nonce uint64, sentAt time.Time) {
+	c.evmNonces[testEVMAddr].pending[nonce] = sentAt
+}
+
+func assertNonces(t *testing.T, c *Client, acceptedNonce, poolNonce uint64, expected ...uint64) {
+	t.Helper()
+	reserved := make([]uint64, len(expected))
+	for i := range expected {
+		reserved[i] = c.testReserve(acceptedNonce, poolNonce, 1)
+	}
+	if !reflect.DeepEqual(reserved, expected) {
+		t.Fatalf("expected nonces %v, got %v", expected, reserved)
+	}
+}
+
+func TestReserveEVMNonces(t *testing.T) {
+	c := newTestNonceClient()
+	if nonce := c.testReserve(5, 7, 1); nonce != 7 {
+		t.Fatalf("expected first nonce from mempool nonce 7, got %d", nonce)
+	}
+	if nonce := c.testReserve(5, 7, 3); nonce != 8 {
+		t.Fatalf("expected range starting at 8, got %d", nonce)
+	}
+	assertNonces(t, c, 5, 7, 11)
+
+	// accepted nonces are forgotten, mempool nonce above next is taken as is
+	assertNonces(t, c, 12, 15, 15)
+	if pending := c.evmNonces[testEVMAddr].pending; len(pending) != 1 {
+		t.Fatalf("expected only nonce 15 pending, got %v", pending)
+	}
+}
+
+func TestReleaseEVMNonce(t *testing.T) {
+	c := newTestNonceClient()
+	assertNonces(t, c, 0, 0, 0, 1, 2, 3)
+
+	// lowest released nonce is reused first
+	c.ReleaseEVMNonce(testEVMAddr, 2)
+	c.ReleaseEVMNonce(testEVMAddr, 1)
+	assertNonces(t, c, 0, 0, 1, 2, 4)
+
+	// trailing released nonces aren't reserved anymore
+	c.ReleaseEVMNonce(testEVMAddr, 4)
+	c.ReleaseEVMNonce(testEVMAddr, 3)
+	if next := c.evmNonces[testEVMAddr].next; next != 3 {
+		t.Fatalf("expected next nonce 3, got %d", next)
+	}
+
+	// not reserved nonces are ignored
+	c.ReleaseEVMNonce(testEVMAddr, 10)
+	c.ReleaseEVMNonce(common.Address{1}, 0)
+	assertNonces(t, c, 0, 0, 3)
+
+	// released nonces used by someone else are dropped
+	c.ReleaseEVMNonce(testEVMAddr, 1)
+	assertNonces(t, c, 0, 2, 4)
+}
+
+func TestEVMNoncesDropped(t *testing.T) {
+	longAgo := time.Now().Add(-2 * evmNonceDropTimeout)
+
+	t.Run("held reservation isn't dropped", func(t *testing.T) {
+		c := newTestNonceClient()
+		assertNonces(t, c, 0, 0, 0, 1)
+		assertNonces(t, c, 0, 0, 2)
+	})
+
+	t.Run("recently sent tx isn't dropped", func(t *testing.T) {
+		c := newTestNonceClient()
+		assertNonces(t, c, 0, 0, 0)
+		c.markEVMNonceSent(testEVMAddr, 0)
+		assertNonces(t, c, 0, 0, 1)
+	})
+
+	t.Run("sent tx missing in mempool is dropped", func(t *testing.T) {
+		c := newTestNonceClient()
+		assertNonces(t, c, 0, 0, 0, 1, 2, 3)
+		c.testSentAt(0, longAgo)
+		c.testSentAt(1, longAgo)
+		c.testSentAt(3, time.Now())
+		// nonce 2 is still held, trailing nonce 3 isn't reserved anymore
+		assertNonces(t, c, 0, 0, 0, 1, 3, 4)
+	})
+
+	t.Run("tx sent after dropped one is dropped", func(t *testing.T) {
+		c := newTestNonceClient()
+		assertNonces(t, c, 0, 0, 0, 1)
+		c.testSentAt(0, longAgo)
+		c.markEVMNonceSent(testEVMAddr, 1)
+		assertNonces(t, c, 0, 0, 0, 1, 2)
+	})
+
+	t.Run("nonce used by someone else is dropped", func(t *testing.T) {
+		c := newTestNonceClient()
+		assertNonces(t, c, 0, 3, 3, 4)
+		c.markEVMNonceSent(testEVMAddr, 3)
+		c.markEVMNonceSent(testEVMAddr, 4)
+		// other node mempool doesn't know txs of nonces 1-4
+		assertNonces(t, c, 0, 1, 1, 2, 3, 4, 5)
+	})
+}
@@ -0,0 +1,110 @@
+package node
+
+import (
+	"context"
+	"math/big"
+
+	"caminoclient/internal/wallet"
+
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// HDAccount is pair of P-Chain and C-Chain addresses derived with the same index
+type HDAccount struct {
+	Index      uint32         `json:"index"`
+	PPath      string         `json:"pPath"`
+	PAddress   string         `json:"pAddress"`
+	PBalance   *PBalance      `json:"pBalance"`
+	EVMPath    string         `json:"evmPath"`
+	EVMAddress common.Address `json:"evmAddress"`
+	CBalance   *big.Int       `json:"cBalance"`
+	CNonce     uint64         `json:"cNonce"`
+}
+
+// Used returns true if account has balance or sent C-Chain txs
+func (a *HDAccount) Used() bool {
+	return a.PBalance.UTXOs > 0 || a.CBalance.Sign() > 0 || a.CNonce > 0
+}
+
+// ListHDAccounts returns first count accounts of hd wallet with their balances
+func (c *Client) ListHDAccounts(ctx context.Context, hdWallet *wallet.HDWallet, count uint32) ([]*HDAccount, error) {
+	accounts := make([]*HDAccount, 0, count)
+	for i := uint32(0); i < count; i++ {
+		account, err := c.GetHDAccount(ctx, hdWallet, i)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// DiscoverHDAccounts scans accounts of hd wallet until gapLimit consecutive unused accounts are found
+// and returns accounts up to the last used one
+func (c *Client) DiscoverHDAccounts(ctx context.Context, hdWallet *wallet.HDWallet, gapLimit uint32) ([]*HDAccount, error) {
+	c.logger.Infof("Discovering hd wallet accounts with gap limit %d...", gapLimit)
+	accounts := []*HDAccount{}
+	lastUsed := -1
+	for i, gap := uint32(0), uint32(0); gap < gapLimit; i++ {
+		account, err := c.GetHDAccount(ctx, hdWallet, i)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+		if account.Used() {
+			lastUsed = len(accounts) - 1
+			gap = 0
+		} else {
+			gap++
+		}
+	}
+	return accounts[:lastUsed+1], nil
+}
+
+// GetHDAccount derives account with given index and fetches its balances
+func (c *Client) GetHDAccount(ctx context.Context, hdWallet *wallet.HDWallet, index uint32) (*HDAccount, error) {
+	account := &HDAccount{
+		Index:   index,
+		PPath:   wallet.AvaxPath(index),
+		EVMPath: wallet.EVMPath(index),
+	}
+
+	pKey, err := hdWallet.DeriveKey(account.PPath)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	pAddr := pKey.Address()
+	account.PAddress, err = address.Format("P", c.hrp, pAddr[:])
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	account.PBalance, err = c.GetPBalance(ctx, pAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	evmKey, err := hdWallet.DeriveKey(account.EVMPath)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	account.EVMAddress = evm.GetEthAddress(evmKey)
+	account.CBalance, err = c.GetCBalance(ctx, account.EVMAddress)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.client.ReadEVM(ctx, func(ctx context.Context, eth ethclient.Client) error {
+		var err error
+		account.CNonce, err = eth.AcceptedNonceAt(ctx, account.EVMAddress)
+		return err
+	}); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	return account, nil
+}
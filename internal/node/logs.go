@@ -0,0 +1,79 @@
+package node
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/interfaces"
+)
+
+// FilterLogsChunked queries C-Chain logs in block ranges of at most chunkSize blocks,
+// passing each chunk result to handle. If node rejects range (e.g. too many results),
+// chunk is split in half until it consists of a single block.
+// Nil query.ToBlock means latest accepted block.
+func (c *Client) FilterLogsChunked(
+	ctx context.Context,
+	query interfaces.FilterQuery,
+	chunkSize uint64,
+	handle func([]types.Log) error,
+) error {
+	c.logger.Info("Querying C-Chain logs...")
+	fromBlock := uint64(0)
+	if query.FromBlock != nil {
+		fromBlock = query.FromBlock.Uint64()
+	}
+	toBlock := uint64(0)
+	if query.ToBlock != nil {
+		toBlock = query.ToBlock.Uint64()
+	} else {
+		if err := c.client.ReadEVM(ctx, func(ctx context.Context, eth ethclient.Client) error {
+			var err error
+			toBlock, err = eth.BlockNumber(ctx)
+			return err
+		}); err != nil {
+			c.logger.Error(err)
+			return err
+		}
+	}
+	if chunkSize == 0 {
+		chunkSize = 1
+	}
+
+	for fromBlock <= toBlock {
+		chunkToBlock := fromBlock + chunkSize - 1
+		if chunkToBlock > toBlock || chunkToBlock < fromBlock {
+			chunkToBlock = toBlock
+		}
+
+		chunkQuery := query
+		chunkQuery.FromBlock = new(big.Int).SetUint64(fromBlock)
+		chunkQuery.ToBlock = new(big.Int).SetUint64(chunkToBlock)
+		var logs []types.Log
+		if err := c.client.ReadEVM(ctx, func(ctx context.Context, eth ethclient.Client) error {
+			var err error
+			logs, err = eth.FilterLogs(ctx, chunkQuery)
+			return err
+		}); err != nil {
+			if chunkToBlock == fromBlock || ctx.Err() != nil {
+				c.logger.Error(err)
+				return err
+			}
+			chunkSize = (chunkToBlock - fromBlock + 1) / 2
+			c.logger.Debugf("logs query of blocks %d-%d failed, retrying with chunk size %d: %v", fromBlock, chunkToBlock, chunkSize, err)
+			continue
+		}
+		c.logger.Debugf("blocks %d-%d: %d logs", fromBlock, chunkToBlock, len(logs))
+
+		if err := handle(logs); err != nil {
+			c.logger.Error(err)
+			return err
+		}
+		if chunkToBlock == toBlock {
+			break
+		}
+		fromBlock = chunkToBlock + 1
+	}
+	return nil
+}
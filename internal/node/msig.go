@@ -0,0 +1,248 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/utils"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errNotMsigAlias         = errkind.New(errkind.InvalidInput, "address is not a multisig alias")
+	errNotEnoughOwnerKeys   = errkind.New(errkind.SignatureMissing, "not enough alias owner keys to reach threshold")
+	errDuplicateOwner       = errkind.New(errkind.InvalidInput, "duplicate alias owner")
+	errInvalidMsigThreshold = errkind.New(errkind.InvalidInput, "alias threshold must be between 1 and number of owners")
+)
+
+// MultisigAliasInfo is multisig alias definition fetched from node
+type MultisigAliasInfo struct {
+	ID            ids.ShortID   `json:"id"`
+	Address       string        `json:"address"`
+	Threshold     uint32        `json:"threshold"`
+	Owners        []string      `json:"owners"`
+	OwnerIDs      []ids.ShortID `json:"ownerIDs"`
+	Locktime      uint64        `json:"locktime"`
+	Memo          string        `json:"memo,omitempty"`
+	Nonce         uint64        `json:"nonce"`
+	NestedAliases []string      `json:"nestedAliases,omitempty"`
+}
+
+// GetMultisigAlias fetches alias definition and detects which of its owners are aliases themselves
+func (c *Client) GetMultisigAlias(ctx context.Context, aliasID ids.ShortID) (*MultisigAliasInfo, error) {
+	c.logger.Info("Getting P-Chain multisig alias...")
+	alias, err := c.getMultisigAlias(ctx, aliasID)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	for i, ownerID := range alias.OwnerIDs {
+		_, err := c.getMultisigAlias(ctx, ownerID)
+		switch {
+		case errors.Is(err, errNotMsigAlias):
+		case err != nil:
+			c.logger.Error(err)
+			return nil, err
+		default:
+			alias.NestedAliases = append(alias.NestedAliases, alias.Owners[i])
+		}
+	}
+	return alias, nil
+}
+
+func (c *Client) getMultisigAlias(ctx context.Context, aliasID ids.ShortID) (*MultisigAliasInfo, error) {
+	aliasAddr, err := address.Format("P", c.hrp, aliasID[:])
+	if err != nil {
+		return nil, err
+	}
+	reply, err := c.client.GetMultisigAlias(ctx, aliasAddr)
+	if err != nil {
+		// node returns json-rpc error, that only has message of its database.ErrNotFound,
+		// so error can't be matched with errors.Is
+		if strings.Contains(err.Error(), database.ErrNotFound.Error()) {
+			return nil, fmt.Errorf("%w: %s", errNotMsigAlias, aliasAddr)
+		}
+		return nil, err
+	}
+
+	alias := &MultisigAliasInfo{
+		ID:        aliasID,
+		Address:   aliasAddr,
+		Threshold: uint32(reply.Threshold),
+		Owners:    make([]string, len(reply.Addresses)),
+		OwnerIDs:  make([]ids.ShortID, len(reply.Addresses)),
+		Locktime:  uint64(reply.Locktime),
+		Memo:      reply.Memo,
+		Nonce:     uint64(reply.Nonce),
+	}
+	for i, ownerAddrStr := range reply.Addresses {
+		_, _, ownerAddrBytes, err := address.Parse(ownerAddrStr)
+		if err != nil {
+			return nil, err
+		}
+		alias.OwnerIDs[i], err = ids.ToShortID(ownerAddrBytes)
+		if err != nil {
+			return nil, err
+		}
+		alias.Owners[i], err = address.Format("P", c.hrp, ownerAddrBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return alias, nil
+}
+
+// UpdateMsigAliasTx creates tx that replaces owners and threshold of existing alias.
+// Tx must be authorized by current alias owners: ownerKeys must contain
+// at least current threshold number of direct (not nested alias) owner keys.
+func (c *Client) UpdateMsigAliasTx(
+	ctx context.Context,
+	aliasID ids.ShortID,
+	addrs []string,
+	threshold uint32,
+	fundsKey Signer,
+	ownerKeys []Signer,
+	opts ...PSpendOption,
+) (*PTxResult, error) {
+	c.logger.Info("Creating P-Chain MsigAliasTx (update)...")
+	newOwners, err := c.parseSortedAddrs(addrs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMsigOwners(newOwners, threshold); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	currentAlias, err := c.getMultisigAlias(ctx, aliasID)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	// alias definition is replaced as whole, so memo is carried over to not clear it
+	memo, err := decodeAliasMemo(currentAlias.Memo)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	authSigIndices, authSigners, err := ownerSigIndices(currentAlias.OwnerIDs, currentAlias.Threshold, ownerKeys)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	spending, signers, err := c.spendP(ctx, fundsKey, 0, getNetworkVMParams(c.networkID).TxFee, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer spending.release()
+	ins, outs := spending.ins, spending.outs
+
+	tx, err := c.signPTx(ctx, &pTxs.MultisigAliasTx{
+		BaseTx: pTxs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    c.networkID,
+			BlockchainID: constants.PlatformChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+		MultisigAlias: multisig.Alias{
+			ID:   aliasID,
+			Memo: memo,
+			Owners: &secp256k1fx.OutputOwners{
+				Threshold: threshold,
+				Addrs:     newOwners,
+			},
+		},
+		Auth: &secp256k1fx.Input{SigIndices: authSigIndices},
+	}, append(signers, authSigners))
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	spending.commit(tx)
+	result, err := c.newPTxResult("MultisigAliasTx", tx, ins, outs)
+	if err != nil {
+		return nil, err
+	}
+	result.Artifacts = map[string]string{"alias": currentAlias.Address}
+	return result, nil
+}
+
+// decodeAliasMemo decodes alias memo, which node returns as hex string
+func decodeAliasMemo(memo string) ([]byte, error) {
+	if memo == "" {
+		return nil, nil
+	}
+	return formatting.Decode(formatting.HexNC, memo)
+}
+
+// parseSortedAddrs parses bech32 addresses into sorted short ids
+func (c *Client) parseSortedAddrs(addrs []string) ([]ids.ShortID, error) {
+	sorting := utils.NewSorting(len(addrs))
+	for i, addrStr := range addrs {
+		_, _, addrBytes, err := address.Parse(addrStr)
+		if err != nil {
+			c.logger.Error(err)
+			return nil, err
+		}
+		sorting.Addrs[i], err = ids.ToShortID(addrBytes)
+		if err != nil {
+			c.logger.Error(err)
+			return nil, err
+		}
+	}
+	sort.Sort(sorting)
+	return sorting.Addrs, nil
+}
+
+// validateMsigOwners checks sorted alias owners for duplicates and threshold range
+func validateMsigOwners(owners []ids.ShortID, threshold uint32) error {
+	for i := 1; i < len(owners); i++ {
+		if owners[i] == owners[i-1] {
+			return fmt.Errorf("%w: %s", errDuplicateOwner, owners[i])
+		}
+	}
+	if threshold == 0 || int(threshold) > len(owners) {
+		return fmt.Errorf("%w: threshold %d, %d owners", errInvalidMsigThreshold, threshold, len(owners))
+	}
+	return nil
+}
+
+// ownerSigIndices returns sorted indices of owners, whose keys are provided, up to threshold,
+// and keys in the same order
+func ownerSigIndices(owners []ids.ShortID, threshold uint32, keys []Signer) ([]uint32, []Signer, error) {
+	keysByAddr := make(map[ids.ShortID]Signer, len(keys))
+	for _, key := range keys {
+		keysByAddr[key.Address()] = key
+	}
+	sigIndices := make([]uint32, 0, threshold)
+	signers := make([]Signer, 0, threshold)
+	for i, owner := range owners {
+		if uint32(len(sigIndices)) == threshold {
+			break
+		}
+		if key, ok := keysByAddr[owner]; ok {
+			sigIndices = append(sigIndices, uint32(i))
+			signers = append(signers, key)
+		}
+	}
+	if uint32(len(sigIndices)) < threshold {
+		return nil, nil, fmt.Errorf("%w: have %d, need %d", errNotEnoughOwnerKeys, len(sigIndices), threshold)
+	}
+	return sigIndices, signers, nil
+}
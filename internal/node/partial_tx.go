@@ -0,0 +1,192 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"caminoclient/internal/audit"
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	errNoSignersInfo   = errkind.New(errkind.InvalidInput, "tx result has no signers info")
+	errCredsMismatch   = errkind.New(errkind.InvalidInput, "partial tx signers don't match its credentials")
+	errNotPartialOwner = errkind.New(errkind.InvalidInput, "none of keys is missing signer of tx")
+	errTxNotSigned     = errkind.New(errkind.SignatureMissing, "tx isn't fully signed")
+	errPartialNetwork  = errkind.New(errkind.WrongNetwork, "partial tx signers are addresses of another network")
+)
+
+// PartialPTx is P-Chain tx, that isn't signed by all its signers yet.
+// It is passed between signers as json file, each of them adds own signatures.
+type PartialPTx struct {
+	Type    string     `json:"type"`
+	Tx      string     `json:"tx"`      // hex, missing signatures are zero
+	Signers [][]string `json:"signers"` // P-Chain addresses of signers of every credential
+	Missing []string   `json:"missingSigners"`
+}
+
+// NewPartialPTx returns partial tx of result built with authority
+func (c *Client) NewPartialPTx(result *PTxResult) (*PartialPTx, error) {
+	if result.signers == nil {
+		c.logger.Error(errNoSignersInfo)
+		return nil, errNoSignersInfo
+	}
+	partial := &PartialPTx{
+		Type:    result.Type,
+		Signers: make([][]string, len(result.signers)),
+	}
+	for i, credSigners := range result.signers {
+		partial.Signers[i] = make([]string, len(credSigners))
+		for j, signer := range credSigners {
+			signerAddr, err := address.Format("P", c.hrp, signer[:])
+			if err != nil {
+				c.logger.Error(err)
+				return nil, err
+			}
+			partial.Signers[i][j] = signerAddr
+		}
+	}
+	if err := c.updatePartialPTx(partial, result.Tx, result.signers); err != nil {
+		return nil, err
+	}
+	return partial, nil
+}
+
+// ReadPartialPTx reads partial tx from json file
+func ReadPartialPTx(path string) (*PartialPTx, error) {
+	partialBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	partial := &PartialPTx{}
+	if err := json.Unmarshal(partialBytes, partial); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return partial, nil
+}
+
+// Write writes partial tx to json file
+func (p *PartialPTx) Write(path string) error {
+	partialBytes, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, partialBytes, 0o600)
+}
+
+// SignPartialPTx adds signatures of keys, that are missing signers of tx
+func (c *Client) SignPartialPTx(ctx context.Context, partial *PartialPTx, keys []Signer) error {
+	tx, signers, err := c.parsePartialPTx(partial)
+	if err != nil {
+		return err
+	}
+	req, err := c.pSigningRequest(ctx, tx.Unsigned, signers, keyAddrs(keys))
+	if err != nil {
+		c.logger.Error(err)
+		return err
+	}
+	reservation, err := c.authorize(req)
+	if err != nil {
+		c.logger.Error(err)
+		return err
+	}
+	added, err := addPTxSignatures(tx, signers, keys)
+	if err != nil {
+		reservation.Release()
+		c.logger.Error(err)
+		return err
+	}
+	if added == 0 {
+		reservation.Release()
+		c.logger.Error(errNotPartialOwner)
+		return errNotPartialOwner
+	}
+	if err := c.auditTx(audit.EventSigned, req, tx.ID(), tx.Bytes()); err != nil {
+		reservation.Release()
+		c.logger.Error(err)
+		return err
+	}
+	c.holdReservation(tx.Unsigned.Bytes(), reservation)
+	c.logger.Infof("added %d signatures", added)
+	return c.updatePartialPTx(partial, tx, signers)
+}
+
+// SignedPTx returns tx of partial tx, if it's fully signed
+func (c *Client) SignedPTx(partial *PartialPTx) (*pTxs.Tx, error) {
+	tx, signers, err := c.parsePartialPTx(partial)
+	if err != nil {
+		return nil, err
+	}
+	if missing := missingPTxSigners(tx, signers); len(missing) > 0 {
+		err := fmt.Errorf("%w: %d signatures missing", errTxNotSigned, len(missing))
+		c.logger.Error(err)
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (c *Client) parsePartialPTx(partial *PartialPTx) (*pTxs.Tx, [][]ids.ShortID, error) {
+	txBytes, err := formatting.Decode(formatting.Hex, partial.Tx)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, nil, err
+	}
+	tx, err := pTxs.Parse(pTxs.Codec, txBytes)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, nil, err
+	}
+	if len(tx.Creds) != len(partial.Signers) {
+		c.logger.Error(errCredsMismatch)
+		return nil, nil, errCredsMismatch
+	}
+	signers := make([][]ids.ShortID, len(partial.Signers))
+	for i, credSigners := range partial.Signers {
+		signers[i] = make([]ids.ShortID, len(credSigners))
+		for j, signerAddr := range credSigners {
+			_, hrp, addrBytes, err := address.Parse(signerAddr)
+			if err != nil {
+				c.logger.Error(err)
+				return nil, nil, err
+			}
+			if hrp != c.hrp {
+				err := fmt.Errorf("%w: %s, client is connected to %s", errPartialNetwork, hrp, c.hrp)
+				c.logger.Error(err)
+				return nil, nil, err
+			}
+			signers[i][j], err = ids.ToShortID(addrBytes)
+			if err != nil {
+				c.logger.Error(err)
+				return nil, nil, err
+			}
+		}
+	}
+	return tx, signers, nil
+}
+
+// updatePartialPTx sets partial tx bytes and missing signers from tx
+func (c *Client) updatePartialPTx(partial *PartialPTx, tx *pTxs.Tx, signers [][]ids.ShortID) error {
+	txHex, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	if err != nil {
+		c.logger.Error(err)
+		return err
+	}
+	partial.Tx = txHex
+	missing := missingPTxSigners(tx, signers)
+	partial.Missing = make([]string, len(missing))
+	for i, signer := range missing {
+		partial.Missing[i], err = address.Format("P", c.hrp, signer[:])
+		if err != nil {
+			c.logger.Error(err)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package node
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs tx hashes with key of address. It's implemented by *secp256k1.PrivateKey
+// and can be implemented by signers, that keep keys elsewhere, e.g. in hardware wallet.
+// SignHash returns 65 bytes recoverable signature [r || s || v].
+// Public key is needed to derive C-Chain address of signer.
+type Signer interface {
+	Address() ids.ShortID
+	PublicKey() *secp256k1.PublicKey
+	SignHash(hash []byte) ([]byte, error)
+}
+
+// KeySigners returns keys as signers
+func KeySigners(keys []*secp256k1.PrivateKey) []Signer {
+	signers := make([]Signer, len(keys))
+	for i, key := range keys {
+		signers[i] = key
+	}
+	return signers
+}
+
+// EVMAddress returns C-Chain address of signer key
+func EVMAddress(signer Signer) common.Address {
+	return crypto.PubkeyToAddress(*signer.PublicKey().ToECDSA())
+}
@@ -0,0 +1,355 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"caminoclient/internal/audit"
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/policy"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	pLocked "github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Tx types of C-Chain eth txs in signing requests
+const (
+	EVMTxTypeEthTx          = "EthTx" // value transfer or contract call
+	EVMTxTypeContractDeploy = "ContractDeploy"
+	EVMTxTypeERC20Transfer  = "ERC20Transfer"
+	EVMTxTypeERC20Approve   = "ERC20Approve"
+)
+
+var errNotProposalTx = errkind.New(errkind.InvalidInput, "voted proposal id isn't AddProposalTx id")
+
+// SetSigningPolicy makes client authorize every tx with signing policy engine before signing it
+func (c *Client) SetSigningPolicy(engine *policy.Engine) {
+	c.policy = engine
+}
+
+// signPTx signs utx with signers keys, once signing policy authorizes it
+func (c *Client) signPTx(ctx context.Context, utx pTxs.UnsignedTx, signers [][]Signer) (*pTxs.Tx, error) {
+	signerAddrs := make([][]ids.ShortID, len(signers))
+	for i, credSigners := range signers {
+		signerAddrs[i] = keyAddrs(credSigners)
+	}
+	req, err := c.pSigningRequest(ctx, utx, signerAddrs, keyAddrs(flatten(signers)))
+	if err != nil {
+		return nil, err
+	}
+	reservation, err := c.authorize(req)
+	if err != nil {
+		return nil, err
+	}
+	tx := newUnsignedPTx(utx, signerAddrs)
+	if _, err := addPTxSignatures(tx, signerAddrs, flatten(signers)); err != nil {
+		reservation.Release()
+		return nil, err
+	}
+	if err := c.auditTx(audit.EventSigned, req, tx.ID(), tx.Bytes()); err != nil {
+		reservation.Release()
+		return nil, err
+	}
+	c.holdReservation(tx.Unsigned.Bytes(), reservation)
+	return tx, nil
+}
+
+// signCTx signs tx with signers keys, once signing policy authorizes it
+func (c *Client) signCTx(tx *evm.Tx, signers [][]Signer) error {
+	pubKeys := []*secp256k1.PublicKey{}
+	for _, key := range flatten(signers) {
+		pubKeys = append(pubKeys, key.PublicKey())
+	}
+	req := c.cSigningRequest(tx.UnsignedAtomicTx, pubKeys)
+	reservation, err := c.authorize(req)
+	if err != nil {
+		return err
+	}
+	if err := addCTxSignatures(tx, signers); err != nil {
+		reservation.Release()
+		return err
+	}
+	if err := c.auditTx(audit.EventSigned, req, tx.ID(), tx.Bytes()); err != nil {
+		reservation.Release()
+		return err
+	}
+	c.holdReservation(tx.UnsignedAtomicTx.Bytes(), reservation)
+	return nil
+}
+
+// evmSigningRequest describes signing of C-Chain eth tx by key.
+// Destinations are tx recipient and, for erc20 transfer or approve, token recipient or spender.
+// Amount is tx value in nCAM, rounded up; token amounts aren't counted.
+func (c *Client) evmSigningRequest(key Signer, to *common.Address, value *big.Int, data []byte) *policy.Request {
+	req := &policy.Request{
+		NetworkID: c.networkID,
+		Chain:     "C",
+		TxType:    EVMTxTypeEthTx,
+		Keys:      []ids.ShortID{key.Address()},
+		Amount:    weiToNCAM(value),
+	}
+	if to == nil {
+		req.TxType = EVMTxTypeContractDeploy
+		return req
+	}
+	req.Destinations = []policy.Destination{policy.EVMDestination(*to)}
+	if len(data) < 4 {
+		return req
+	}
+	method, err := erc20ABI.MethodById(data[:4])
+	if err != nil || (method.Name != "transfer" && method.Name != "approve") {
+		return req
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return req
+	}
+	if recipient, ok := args[0].(common.Address); ok {
+		req.TxType = EVMTxTypeERC20Transfer
+		if method.Name == "approve" {
+			req.TxType = EVMTxTypeERC20Approve
+		}
+		req.Destinations = append(req.Destinations, policy.EVMDestination(recipient))
+	}
+	return req
+}
+
+// weiToNCAM converts wei to nCAM rounding up, amounts above max uint64 are capped
+func weiToNCAM(wei *big.Int) uint64 {
+	if wei == nil || wei.Sign() <= 0 {
+		return 0
+	}
+	nCAM := new(big.Int).Div(new(big.Int).Add(wei, x2cRateMinus1), x2cRate)
+	if !nCAM.IsUint64() {
+		return math.MaxUint64
+	}
+	return nCAM.Uint64()
+}
+
+// authorize checks request with signing policy, if client has it,
+// and returns reservation of request amount in policy rolling windows
+func (c *Client) authorize(req *policy.Request) (*policy.Reservation, error) {
+	if c.policy == nil {
+		return nil, nil
+	}
+	return c.policy.Authorize(req)
+}
+
+// holdReservation keeps reservation of signed tx until tx is issued. Tx is identified
+// by its unsigned bytes, so partially signed tx keeps reservations of all its signings.
+func (c *Client) holdReservation(unsignedBytes []byte, reservation *policy.Reservation) {
+	if reservation == nil {
+		return
+	}
+	id := ids.ID(hashing.ComputeHash256Array(unsignedBytes))
+	c.reservationsLock.Lock()
+	defer c.reservationsLock.Unlock()
+	c.reservations[id] = append(c.reservations[id], reservation)
+}
+
+// settleReservations commits reservations of tx, if it was issued, or releases them otherwise.
+// If node was unavailable, tx could be issued, so its reservations are committed.
+// Amount of issued tx, which signing wasn't authorized by this client, is recorded.
+func (c *Client) settleReservations(tx *issuedTx, issueErr error) error {
+	if c.policy == nil {
+		return nil
+	}
+	id := ids.ID(hashing.ComputeHash256Array(tx.unsignedBytes))
+	c.reservationsLock.Lock()
+	reservations := c.reservations[id]
+	delete(c.reservations, id)
+	c.reservationsLock.Unlock()
+
+	if issueErr != nil && !errors.Is(issueErr, errkind.RPCUnavailable) {
+		for _, reservation := range reservations {
+			reservation.Release()
+		}
+		return nil
+	}
+	if len(reservations) == 0 {
+		return c.policy.Record(tx.req)
+	}
+	for _, reservation := range reservations {
+		if err := reservation.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pSigningRequest describes signing of utx with signers by keys with given addresses.
+// Outputs owned only by signers are change and aren't counted as sent.
+func (c *Client) pSigningRequest(
+	ctx context.Context,
+	utx pTxs.UnsignedTx,
+	signers [][]ids.ShortID,
+	keys []ids.ShortID,
+) (*policy.Request, error) {
+	txSigners := map[ids.ShortID]struct{}{}
+	for _, credSigners := range signers {
+		for _, signer := range credSigners {
+			txSigners[signer] = struct{}{}
+		}
+	}
+	req := &policy.Request{
+		NetworkID: c.networkID,
+		Chain:     "P",
+		TxType:    txTypeName(utx),
+		Keys:      make([]ids.ShortID, 0, len(keys)),
+	}
+	for _, key := range keys {
+		if _, ok := txSigners[key]; ok {
+			req.Keys = append(req.Keys, key)
+		}
+	}
+
+	outs := utx.Outputs()
+	if exportTx, ok := utx.(*pTxs.ExportTx); ok {
+		outs = append(append([]*avax.TransferableOutput{}, outs...), exportTx.ExportedOutputs...)
+	}
+	c.addSentOutputs(req, outs, txSigners)
+
+	// proposal kind is only needed by policy, voted proposal kind costs node call
+	if c.policy == nil {
+		return req, nil
+	}
+	var err error
+	switch utx := utx.(type) {
+	case *pTxs.AddProposalTx:
+		req.ProposalKind, err = proposalKind(utx.ProposalPayload)
+	case *pTxs.AddVoteTx:
+		req.ProposalKind, err = c.votedProposalKind(ctx, utx.ProposalID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// cSigningRequest describes signing of utx by signers.
+// Outputs owned only by signers are moved between their chains and aren't counted as sent.
+func (c *Client) cSigningRequest(utx evm.UnsignedAtomicTx, signers []*secp256k1.PublicKey) *policy.Request {
+	req := &policy.Request{
+		NetworkID: c.networkID,
+		Chain:     "C",
+		TxType:    txTypeName(utx),
+	}
+	txSigners := map[ids.ShortID]struct{}{}
+	evmSigners := map[common.Address]struct{}{}
+	for _, pubKey := range signers {
+		req.Keys = append(req.Keys, pubKey.Address())
+		txSigners[pubKey.Address()] = struct{}{}
+		evmSigners[crypto.PubkeyToAddress(*pubKey.ToECDSA())] = struct{}{}
+	}
+
+	switch utx := utx.(type) {
+	case *evm.UnsignedExportTx:
+		c.addSentOutputs(req, utx.ExportedOutputs, txSigners)
+	case *evm.UnsignedImportTx:
+		for _, out := range utx.Outs {
+			if _, ok := evmSigners[out.Address]; ok || out.AssetID != c.avaxAssetID {
+				continue
+			}
+			req.Amount += out.Amount
+			req.Destinations = append(req.Destinations, policy.EVMDestination(out.Address))
+		}
+	}
+	return req
+}
+
+// addSentOutputs adds avax amount and owners of outputs, that aren't owned only by signers, to request
+func (c *Client) addSentOutputs(req *policy.Request, outs []*avax.TransferableOutput, signers map[ids.ShortID]struct{}) {
+	for _, out := range c.sentOutputs(outs, signers) {
+		req.Amount += out.Out.Amount()
+		for _, owner := range outputOwners(out) {
+			req.Destinations = append(req.Destinations, policy.AddressDestination(owner))
+		}
+	}
+}
+
+// sentOutputs returns avax outputs, that aren't owned only by signers
+func (c *Client) sentOutputs(outs []*avax.TransferableOutput, signers map[ids.ShortID]struct{}) []*avax.TransferableOutput {
+	sent := []*avax.TransferableOutput{}
+	for _, out := range outs {
+		if out.AssetID() != c.avaxAssetID {
+			continue
+		}
+		owners := outputOwners(out)
+		isSent := len(owners) == 0
+		for _, owner := range owners {
+			if _, ok := signers[owner]; !ok {
+				isSent = true
+			}
+		}
+		if isSent {
+			sent = append(sent, out)
+		}
+	}
+	return sent
+}
+
+func outputOwners(out *avax.TransferableOutput) []ids.ShortID {
+	innerOut := out.Out
+	if lockedOut, ok := innerOut.(*pLocked.Out); ok {
+		innerOut = lockedOut.TransferableOut
+	}
+	if transferOut, ok := innerOut.(*secp256k1fx.TransferOutput); ok {
+		return transferOut.Addrs
+	}
+	return nil
+}
+
+// votedProposalKind returns kind of proposal, which id is id of tx, that added it
+func (c *Client) votedProposalKind(ctx context.Context, proposalID ids.ID) (string, error) {
+	tx, err := c.GetPTX(ctx, proposalID)
+	if err != nil {
+		return "", err
+	}
+	proposalTx, ok := tx.Unsigned.(*pTxs.AddProposalTx)
+	if !ok {
+		return "", errNotProposalTx
+	}
+	return proposalKind(proposalTx.ProposalPayload)
+}
+
+// proposalKind returns proposal type name without Proposal suffix, e.g. BaseFee
+func proposalKind(proposalBytes []byte) (string, error) {
+	wrapper := &pTxs.ProposalWrapper{}
+	if _, err := pTxs.Codec.Unmarshal(proposalBytes, wrapper); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(reflect.TypeOf(wrapper.Proposal).Elem().Name(), "Proposal"), nil
+}
+
+func keyAddrs[S Signer](keys []S) []ids.ShortID {
+	addrs := make([]ids.ShortID, len(keys))
+	for i, key := range keys {
+		addrs[i] = key.Address()
+	}
+	return addrs
+}
+
+func flatten[T any](slices [][]T) []T {
+	flat := []T{}
+	for _, slice := range slices {
+		flat = append(flat, slice...)
+	}
+	return flat
+}
+
+// txTypeName returns unsigned tx type name, e.g. BaseTx or ExportTx
+func txTypeName(utx interface{}) string {
+	return strings.TrimPrefix(reflect.TypeOf(utx).Elem().Name(), "Unsigned")
+}
@@ -0,0 +1,149 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errNoRecipients         = errkind.New(errkind.InvalidInput, "no recipients")
+	errZeroTransferAmount   = errkind.New(errkind.InvalidInput, "transfer amount must be positive")
+	errMemoTooLarge         = errkind.New(errkind.InvalidInput, "memo is too large")
+	errInsufficientUnlocked = errkind.New(errkind.InsufficientFunds, "insufficient unlocked funds")
+)
+
+// TransferRecipient is destination of P-Chain transfer: owners, which
+// threshold of can spend transferred amount after locktime
+type TransferRecipient struct {
+	Amount    uint64   `json:"amount"`
+	Owners    []string `json:"owners"` // bech32 addresses
+	Threshold uint32   `json:"threshold"`
+	Locktime  uint64   `json:"locktime,omitempty"`
+}
+
+// TransferTx creates BaseTx, that sends avax from fundsKey unlocked utxos to recipients
+func (c *Client) TransferTx(
+	ctx context.Context,
+	recipients []*TransferRecipient,
+	memo []byte,
+	fundsKey Signer,
+	opts ...PSpendOption,
+) (*PTxResult, error) {
+	c.logger.Info("Creating P-Chain BaseTx...")
+	if len(memo) > avax.MaxMemoSize {
+		err := fmt.Errorf("%w: %d bytes, max %d", errMemoTooLarge, len(memo), avax.MaxMemoSize)
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	outs, amount, err := c.transferOutputs(recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := getNetworkVMParams(c.networkID).TxFee
+	amountToBurn, err := math.Add64(amount, fee)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	if err := c.checkUnlockedBalance(ctx, fundsKey, amountToBurn, opts...); err != nil {
+		return nil, err
+	}
+
+	// amount is burned from spender perspective and then produced by recipient outputs
+	spending, signers, err := c.spendP(ctx, fundsKey, 0, amountToBurn, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer spending.release()
+	ins := spending.ins
+	outs = append(outs, spending.outs...)
+	avax.SortTransferableOutputs(outs, pTxs.Codec)
+
+	tx, err := c.signPTx(ctx, &pTxs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    c.networkID,
+		BlockchainID: constants.PlatformChainID,
+		Ins:          ins,
+		Outs:         outs,
+		Memo:         memo,
+	}}, signers)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	spending.commit(tx)
+	return c.newPTxResult("BaseTx", tx, ins, outs)
+}
+
+// transferOutputs returns unsorted outputs, that transfer avax to recipients, and their total amount
+func (c *Client) transferOutputs(recipients []*TransferRecipient) ([]*avax.TransferableOutput, uint64, error) {
+	if len(recipients) == 0 {
+		c.logger.Error(errNoRecipients)
+		return nil, 0, errNoRecipients
+	}
+	outs := make([]*avax.TransferableOutput, len(recipients))
+	amount := uint64(0)
+	for i, recipient := range recipients {
+		if recipient.Amount == 0 {
+			c.logger.Error(errZeroTransferAmount)
+			return nil, 0, errZeroTransferAmount
+		}
+		owners, err := c.parseSortedAddrs(recipient.Owners)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := validateMsigOwners(owners, recipient.Threshold); err != nil {
+			c.logger.Error(err)
+			return nil, 0, err
+		}
+		amount, err = math.Add64(amount, recipient.Amount)
+		if err != nil {
+			c.logger.Error(err)
+			return nil, 0, err
+		}
+		outs[i] = &avax.TransferableOutput{
+			Asset: avax.Asset{ID: c.avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: recipient.Amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Locktime:  recipient.Locktime,
+					Threshold: recipient.Threshold,
+					Addrs:     owners,
+				},
+			},
+		}
+	}
+	return outs, amount, nil
+}
+
+// checkUnlockedBalance returns descriptive error, if fundsKey doesn't have enough unlocked funds.
+// Check is skipped for txs spending from utxo pool, which checks its own balance.
+func (c *Client) checkUnlockedBalance(ctx context.Context, fundsKey Signer, amount uint64, opts ...PSpendOption) error {
+	options := &pSpendOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.pool != nil {
+		return nil
+	}
+	balance, err := c.GetPBalance(ctx, fundsKey.Address())
+	if err != nil {
+		return err
+	}
+	if balance.Unlocked < amount {
+		err := fmt.Errorf("%w: needs %d, has %d unlocked of %d total",
+			errInsufficientUnlocked, amount, balance.Unlocked, balance.Total)
+		c.logger.Error(err)
+		return err
+	}
+	return nil
+}
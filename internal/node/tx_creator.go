@@ -1,70 +1,51 @@
 package node
 
 import (
-	"caminoclient/internal/utils"
 	"context"
 	"errors"
-	"fmt"
 	"math/big"
-	"sort"
 
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
-	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
-	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/hashing"
-	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/multisig"
 	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/dac"
-	pLocked "github.com/ava-labs/avalanchego/vms/platformvm/locked"
 	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
-	"github.com/ava-labs/coreth/plugin/evm"
 	"github.com/ethereum/go-ethereum/common"
 )
 
-func (c *Client) MsigAliasTx(addrs []string, threshold uint32, fundsKey *secp256k1.PrivateKey) (*pTxs.Tx, error) {
+// MsigAliasTxResult is created MultisigAliasTx with alias it defines
+type MsigAliasTxResult struct {
+	*PTxResult
+	AliasID      ids.ShortID `json:"aliasID"`
+	AliasAddress string      `json:"aliasAddress"`
+	Threshold    uint32      `json:"threshold"`
+	Owners       []string    `json:"owners"` // sorted
+}
+
+func (c *Client) MsigAliasTx(ctx context.Context, addrs []string, threshold uint32, fundsKey Signer, opts ...PSpendOption) (*MsigAliasTxResult, error) {
 	c.logger.Info("Creating P-Chain MsigAliasTx...")
-	sorting := utils.NewSorting(len(addrs))
-	for i, argAddrStr := range addrs {
-		_, _, addrBytes, err := address.Parse(argAddrStr)
-		if err != nil {
-			c.logger.Error(err)
-			return nil, err
-		}
-		addr, err := ids.ToShortID(addrBytes)
-		if err != nil {
-			c.logger.Error(err)
-			return nil, err
-		}
-		addrStr, err := address.Format("P", c.hrp, addr.Bytes())
-		if err != nil {
-			c.logger.Error(err)
-			return nil, err
-		}
-		sorting.Addrs[i] = addr
-		c.logger.Infof("%s : %s : %s", argAddrStr, addr, addrStr)
+	owners, err := c.parseSortedAddrs(addrs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMsigOwners(owners, threshold); err != nil {
+		c.logger.Error(err)
+		return nil, err
 	}
 
-	sort.Sort(sorting)
-
-	ins, outs, err := c.client.SpendP(
-		context.Background(),
-		c.networkID,
-		fundsKey.Address(),
-		fundsKey.Address(),
-		0, getNetworkVMParams(c.networkID).TxFee,
-		pLocked.StateUnlocked,
-	)
+	spending, signers, err := c.spendP(ctx, fundsKey, 0, getNetworkVMParams(c.networkID).TxFee, opts...)
 	if err != nil {
-		c.logger.Error(err)
 		return nil, err
 	}
+	defer spending.release()
+	ins, outs := spending.ins, spending.outs
 	utx := &pTxs.MultisigAliasTx{
 		BaseTx: pTxs.BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    c.networkID,
@@ -75,70 +56,63 @@ func (c *Client) MsigAliasTx(addrs []string, threshold uint32, fundsKey *secp256
 		MultisigAlias: multisig.Alias{
 			Owners: &secp256k1fx.OutputOwners{
 				Threshold: threshold,
-				Addrs:     sorting.Addrs,
+				Addrs:     owners,
 			},
 		},
 		Auth: &secp256k1fx.Input{},
 	}
-	signers := make([][]*secp256k1.PrivateKey, len(utx.Ins))
-	for i := range signers {
-		signers[i] = []*secp256k1.PrivateKey{fundsKey}
-	}
-
-	avax.SortTransferableInputsWithSigners(utx.Ins, signers)
-	avax.SortTransferableOutputs(utx.Outs, pTxs.Codec)
-	tx, err := pTxs.NewSigned(utx, pTxs.Codec, signers)
+	tx, err := c.signPTx(ctx, utx, signers)
 	if err != nil {
 		c.logger.Error(err)
 		return nil, err
 	}
-	txEncodedBytes, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	spending.commit(tx)
+	result, err := c.newPTxResult("MultisigAliasTx", tx, utx.Ins, utx.Outs)
 	if err != nil {
-		c.logger.Error(err)
 		return nil, err
 	}
-	c.logger.Info(txEncodedBytes)
-	c.logger.Infof("txID: %s", tx.ID())
 
 	aliasID := multisig.ComputeAliasID(tx.ID())
-	aliasAddrStr, err := address.Format("P", constants.GetHRP(constants.CaminoID), aliasID.Bytes())
+	aliasAddrStr, err := address.Format("P", c.hrp, aliasID.Bytes())
 	if err != nil {
 		c.logger.Error(err)
 		return nil, err
 	}
-	owners := utx.MultisigAlias.Owners.(*secp256k1fx.OutputOwners)
-	aliasAddrs, err := c.utils.AddressesFromIDs(owners.Addrs, c.networkID)
+	ownerAddrs, err := c.utils.AddressesFromIDs(owners, c.networkID)
 	if err != nil {
 		c.logger.Error(err)
 		return nil, err
 	}
 	c.logger.Infof("alias: %s", aliasAddrStr)
-	c.logger.Infof("alias definition: {\n    threshold: %d\n    addresses: %v\n}", owners.Threshold, aliasAddrs)
-	return tx, nil
+	c.logger.Infof("alias definition: {\n    threshold: %d\n    addresses: %v\n}", threshold, ownerAddrs)
+	result.Artifacts = map[string]string{"alias": aliasAddrStr}
+	return &MsigAliasTxResult{
+		PTxResult:    result,
+		AliasID:      aliasID,
+		AliasAddress: aliasAddrStr,
+		Threshold:    threshold,
+		Owners:       ownerAddrs,
+	}, nil
 }
 
-func (c *Client) AddressStateTx(address ids.ShortID, state as.AddressStateBit, remove bool, fundsKey, executorKey *secp256k1.PrivateKey) (*pTxs.Tx, error) {
+// AddressStateTx creates tx that sets or removes address state bit, authorized by executor.
+// Executor can be multisig alias, see Authority.
+func (c *Client) AddressStateTx(ctx context.Context, address ids.ShortID, state as.AddressStateBit, remove bool, fundsKey Signer, executor *Authority, opts ...PSpendOption) (*PTxResult, error) {
 	c.logger.Info("Creating P-Chain AddressStateTx...")
-	ins, outs, err := c.client.SpendP(
-		context.Background(),
-		c.networkID,
-		fundsKey.Address(),
-		fundsKey.Address(),
-		0, getNetworkVMParams(c.networkID).TxFee,
-		pLocked.StateUnlocked,
-	)
+	executorAuth, executorSigners, err := c.resolveAuth(ctx, executor)
 	if err != nil {
 		c.logger.Error(err)
 		return nil, err
 	}
-	signers := make([][]*secp256k1.PrivateKey, len(ins))
-	for i := range signers {
-		signers[i] = []*secp256k1.PrivateKey{fundsKey}
+
+	spending, signers, err := c.spendP(ctx, fundsKey, 0, getNetworkVMParams(c.networkID).TxFee, opts...)
+	if err != nil {
+		return nil, err
 	}
-	avax.SortTransferableInputsWithSigners(ins, signers)
-	avax.SortTransferableOutputs(outs, pTxs.Codec)
+	defer spending.release()
+	ins, outs := spending.ins, spending.outs
 
-	tx, err := pTxs.NewSigned(&pTxs.AddressStateTx{
+	tx, credSigners, err := c.signAuthPTx(ctx, &pTxs.AddressStateTx{
 		BaseTx: pTxs.BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    c.networkID,
 			BlockchainID: constants.PlatformChainID,
@@ -148,48 +122,39 @@ func (c *Client) AddressStateTx(address ids.ShortID, state as.AddressStateBit, r
 		Address:      address,
 		State:        state,
 		Remove:       remove,
-		Executor:     executorKey.Address(),
-		ExecutorAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
-	}, pTxs.Codec, append(signers, []*secp256k1.PrivateKey{executorKey}))
+		Executor:     executor.Address,
+		ExecutorAuth: executorAuth,
+	}, signers, executorSigners, executor)
 	if err != nil {
 		c.logger.Error(err)
 		return nil, err
 	}
-	txEncodedBytes, err := formatting.Encode(formatting.Hex, tx.Bytes())
-	if err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-	c.logger.Info(txEncodedBytes)
-	c.logger.Infof("txID: %s", tx.ID())
-	return tx, nil
+	return c.newAuthPTxResult("AddressStateTx", tx, credSigners, spending)
 }
 
+// ProposalTx creates tx that adds dac proposal, authorized by proposer.
+// Proposer can be multisig alias, see Authority.
 func (c *Client) ProposalTx(
+	ctx context.Context,
 	proposal dac.Proposal,
-	fundsKey *secp256k1.PrivateKey,
-	proposerKey *secp256k1.PrivateKey,
-) (*pTxs.Tx, error) {
+	fundsKey Signer,
+	proposer *Authority,
+	opts ...PSpendOption,
+) (*PTxResult, error) {
 	c.logger.Info("Creating P-Chain AddProposalTx...")
-	vmParams := getNetworkVMParams(c.networkID)
-	ins, outs, err := c.client.SpendP(
-		context.Background(),
-		c.networkID,
-		fundsKey.Address(),
-		fundsKey.Address(),
-		vmParams.CaminoConfig.DACProposalBondAmount, vmParams.TxFee,
-		pLocked.StateUnlocked,
-	)
+	proposerAuth, proposerSigners, err := c.resolveAuth(ctx, proposer)
 	if err != nil {
 		c.logger.Error(err)
 		return nil, err
 	}
-	signers := make([][]*secp256k1.PrivateKey, len(ins))
-	for i := range signers {
-		signers[i] = []*secp256k1.PrivateKey{fundsKey}
+
+	vmParams := getNetworkVMParams(c.networkID)
+	spending, signers, err := c.spendP(ctx, fundsKey, vmParams.CaminoConfig.DACProposalBondAmount, vmParams.TxFee, opts...)
+	if err != nil {
+		return nil, err
 	}
-	avax.SortTransferableInputsWithSigners(ins, signers)
-	avax.SortTransferableOutputs(outs, pTxs.Codec)
+	defer spending.release()
+	ins, outs := spending.ins, spending.outs
 
 	wrappedProposal := &pTxs.ProposalWrapper{Proposal: proposal}
 	proposalBytes, err := pTxs.Codec.Marshal(pTxs.Version, wrappedProposal)
@@ -198,7 +163,7 @@ func (c *Client) ProposalTx(
 		return nil, err
 	}
 
-	tx, err := pTxs.NewSigned(&pTxs.AddProposalTx{
+	tx, credSigners, err := c.signAuthPTx(ctx, &pTxs.AddProposalTx{
 		BaseTx: pTxs.BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    c.networkID,
 			BlockchainID: constants.PlatformChainID,
@@ -206,48 +171,44 @@ func (c *Client) ProposalTx(
 			Outs:         outs,
 		}},
 		ProposalPayload: proposalBytes,
-		ProposerAddress: proposerKey.Address(),
-		ProposerAuth:    &secp256k1fx.Input{SigIndices: []uint32{0}},
-	}, pTxs.Codec, append(signers, []*secp256k1.PrivateKey{proposerKey}))
+		ProposerAddress: proposer.Address,
+		ProposerAuth:    proposerAuth,
+	}, signers, proposerSigners, proposer)
 	if err != nil {
 		c.logger.Error(err)
 		return nil, err
 	}
-	txEncodedBytes, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	result, err := c.newAuthPTxResult("AddProposalTx", tx, credSigners, spending)
 	if err != nil {
-		c.logger.Error(err)
 		return nil, err
 	}
-	fmt.Printf("%s\n", txEncodedBytes)
-	fmt.Printf("txID: %s\n", tx.ID())
-	return tx, nil
+	result.Artifacts = map[string]string{"proposalID": tx.ID().String()}
+	return result, nil
 }
 
+// VoteTx creates tx that votes for proposal option, authorized by voter.
+// Voter can be multisig alias, see Authority.
 func (c *Client) VoteTx(
+	ctx context.Context,
 	proposalID ids.ID,
 	optionIndex uint32,
-	fundsKey *secp256k1.PrivateKey,
-	voterKey *secp256k1.PrivateKey,
-) (*pTxs.Tx, error) {
+	fundsKey Signer,
+	voter *Authority,
+	opts ...PSpendOption,
+) (*PTxResult, error) {
 	c.logger.Info("Creating P-Chain AddVoteTx...")
-	ins, outs, err := c.client.SpendP(
-		context.Background(),
-		c.networkID,
-		fundsKey.Address(),
-		fundsKey.Address(),
-		0, getNetworkVMParams(c.networkID).TxFee,
-		pLocked.StateUnlocked,
-	)
+	voterAuth, voterSigners, err := c.resolveAuth(ctx, voter)
 	if err != nil {
 		c.logger.Error(err)
 		return nil, err
 	}
-	signers := make([][]*secp256k1.PrivateKey, len(ins))
-	for i := range signers {
-		signers[i] = []*secp256k1.PrivateKey{fundsKey}
+
+	spending, signers, err := c.spendP(ctx, fundsKey, 0, getNetworkVMParams(c.networkID).TxFee, opts...)
+	if err != nil {
+		return nil, err
 	}
-	avax.SortTransferableInputsWithSigners(ins, signers)
-	avax.SortTransferableOutputs(outs, pTxs.Codec)
+	defer spending.release()
+	ins, outs := spending.ins, spending.outs
 
 	vote := &pTxs.VoteWrapper{Vote: &dac.SimpleVote{OptionIndex: optionIndex}}
 	voteBytes, err := pTxs.Codec.Marshal(pTxs.Version, vote)
@@ -256,7 +217,7 @@ func (c *Client) VoteTx(
 		return nil, err
 	}
 
-	tx, err := pTxs.NewSigned(&pTxs.AddVoteTx{
+	tx, credSigners, err := c.signAuthPTx(ctx, &pTxs.AddVoteTx{
 		BaseTx: pTxs.BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    c.networkID,
 			BlockchainID: constants.PlatformChainID,
@@ -265,21 +226,14 @@ func (c *Client) VoteTx(
 		}},
 		ProposalID:   proposalID,
 		VotePayload:  voteBytes,
-		VoterAddress: voterKey.Address(),
-		VoterAuth:    &secp256k1fx.Input{SigIndices: []uint32{0}},
-	}, pTxs.Codec, append(signers, []*secp256k1.PrivateKey{voterKey}))
+		VoterAddress: voter.Address,
+		VoterAuth:    voterAuth,
+	}, signers, voterSigners, voter)
 	if err != nil {
 		c.logger.Error(err)
 		return nil, err
 	}
-	txEncodedBytes, err := formatting.Encode(formatting.Hex, tx.Bytes())
-	if err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-	fmt.Printf("%s\n", txEncodedBytes)
-	fmt.Printf("txID: %s\n", tx.ID())
-	return tx, nil
+	return c.newAuthPTxResult("AddVoteTx", tx, credSigners, spending)
 }
 
 func getNetworkVMParams(networkID uint32) *genesis.Params {
@@ -294,23 +248,8 @@ func getNetworkVMParams(networkID uint32) *genesis.Params {
 	return &genesis.KopernikusParams
 }
 
-func (c *Client) EVMTx(amountToExport uint64, recipientAddr ids.ShortID, fundsKey *secp256k1.PrivateKey, targetChain string) (*evm.Tx, error) {
-	c.logger.Info("Creating C-Chain exportTx...")
-
-	destinationChainID, err := c.getChainID(targetChain)
-	if err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-
-	senderAddr := evm.GetEthAddress(fundsKey)
-	nonce, err := c.client.CETH.NonceAt(context.Background(), senderAddr, nil)
-	if err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-
-	amountToConsume := amountToExport
+// EVMTx creates C-Chain ExportTx, that exports amount from fundsKey evm address to recipientAddr on targetChain
+func (c *Client) EVMTx(ctx context.Context, amountToExport uint64, recipientAddr ids.ShortID, fundsKey Signer, targetChain string) (*CTxResult, error) {
 	outs := []*avax.TransferableOutput{{
 		Asset: avax.Asset{ID: c.avaxAssetID},
 		Out: &secp256k1fx.TransferOutput{
@@ -321,81 +260,7 @@ func (c *Client) EVMTx(amountToExport uint64, recipientAddr ids.ShortID, fundsKe
 			},
 		},
 	}}
-
-	// calculate fee
-
-	utx := &evm.UnsignedExportTx{
-		NetworkID:        c.networkID,
-		BlockchainID:     c.cChainID,
-		DestinationChain: destinationChainID,
-		ExportedOutputs:  outs,
-	}
-	tx := &evm.Tx{UnsignedAtomicTx: utx}
-	if err := tx.Sign(evm.Codec, nil); err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-
-	txGasUsed, err := tx.GasUsed(true)
-	if err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-
-	baseFee, err := c.client.CETH.EstimateBaseFee(context.Background())
-	if err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-
-	newTxGasUsed, err := math.Add64(txGasUsed, EVMInputGas)
-	if err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-	txGasUsed = newTxGasUsed
-
-	fee, err := calculateEVMDynamicFee(txGasUsed, baseFee)
-	if err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-
-	newAmount, err := math.Add64(amountToConsume, fee)
-	if err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-	amountToConsume = newAmount
-
-	// create tx
-
-	utx = &evm.UnsignedExportTx{
-		NetworkID:        c.networkID,
-		BlockchainID:     c.cChainID,
-		DestinationChain: destinationChainID,
-		Ins: []evm.EVMInput{{
-			Address: senderAddr,
-			Amount:  amountToConsume,
-			AssetID: c.avaxAssetID,
-			Nonce:   nonce,
-		}},
-		ExportedOutputs: outs,
-	}
-	tx = &evm.Tx{UnsignedAtomicTx: utx}
-	if err := tx.Sign(evm.Codec, [][]*secp256k1.PrivateKey{{fundsKey}}); err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-
-	txEncodedBytes, err := formatting.Encode(formatting.Hex, tx.Bytes())
-	if err != nil {
-		c.logger.Error(err)
-		return nil, err
-	}
-	c.logger.Info(txEncodedBytes)
-	c.logger.Infof("txID: %s", tx.ID())
-	return tx, nil
+	return c.evmExportTx(ctx, outs, amountToExport, []Signer{fundsKey}, targetChain)
 }
 
 // copy-paste from evm
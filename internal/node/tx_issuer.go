@@ -2,26 +2,163 @@ package node
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/coreth/plugin/evm"
+)
+
+const txPollInterval = time.Second
+
+var (
+	errPTxNotCommitted = errkind.New(errkind.TxRejected, "tx wasn't committed")
+	errCTxNotAccepted  = errkind.New(errkind.TxRejected, "tx wasn't accepted")
+	// tx is issued, but its issuance couldn't be recorded to audit log
+	errIssuedNotAudited = errors.New("issued tx wasn't recorded to audit log")
+	// tx is issued, but its amount couldn't be saved to signing policy state
+	errIssuedNotCommitted = errors.New("issued tx amount wasn't saved to signing policy state")
 )
 
-func (c *Client) IssuePTx(txBytes []byte) error {
+// IssuePTx issues signed P-Chain tx, recording it to audit log, if client has it
+func (c *Client) IssuePTx(ctx context.Context, txBytes []byte) error {
 	c.logger.Info("Issuing P-Chain tx...")
-	txID, err := c.client.P.IssueTx(context.Background(), txBytes)
-	if err != nil {
+	if err := c.issueAudited(txBytes,
+		func() (*issuedTx, error) { return c.issuedPTx(ctx, txBytes) },
+		func() error {
+			txID, err := c.client.IssuePTx(ctx, txBytes)
+			if err == nil {
+				c.logger.Infof("\ntx %s issued!\n\n", txID)
+			}
+			return err
+		},
+	); err != nil {
 		c.logger.Error(err)
 		return err
 	}
-	c.logger.Infof("\ntx %s issued!\n\n", txID)
 	return nil
 }
 
-func (c *Client) IssueCTx(txBytes []byte) error {
+// IssueCTx issues signed C-Chain atomic tx, recording it to audit log, if client has it
+func (c *Client) IssueCTx(ctx context.Context, txBytes []byte) error {
 	c.logger.Info("Issuing C-Chain tx...")
-	txID, err := c.client.C.IssueTx(context.Background(), txBytes)
-	if err != nil {
+	if err := c.issueAudited(txBytes,
+		func() (*issuedTx, error) { return c.issuedCTx(txBytes) },
+		func() error {
+			txID, err := c.client.IssueCTx(ctx, txBytes)
+			if err == nil {
+				c.logger.Infof("\ntx %s issued!\n\n", txID)
+			}
+			return err
+		},
+	); err != nil {
 		c.logger.Error(err)
 		return err
 	}
-	c.logger.Infof("\ntx %s issued!\n\n", txID)
+	c.markCTxNoncesSent(txBytes)
 	return nil
 }
+
+// markCTxNoncesSent marks nonces of export tx inputs as sent, if they were reserved by this client
+func (c *Client) markCTxNoncesSent(txBytes []byte) {
+	tx := &evm.Tx{}
+	if _, err := evm.Codec.Unmarshal(txBytes, tx); err != nil {
+		return
+	}
+	if exportTx, ok := tx.UnsignedAtomicTx.(*evm.UnsignedExportTx); ok {
+		for _, in := range exportTx.Ins {
+			c.markEVMNonceSent(in.Address, in.Nonce)
+		}
+	}
+}
+
+// GetPTxStatus returns status of P-Chain tx and reason of its rejection, if it was rejected
+func (c *Client) GetPTxStatus(ctx context.Context, txID ids.ID) (status.Status, string, error) {
+	var reply *platformvm.GetTxStatusResponse
+	if err := c.client.ReadP(ctx, func(ctx context.Context, p platformvm.Client) error {
+		var err error
+		reply, err = p.GetTxStatus(ctx, txID)
+		return err
+	}); err != nil {
+		c.logger.Error(err)
+		return status.Unknown, "", err
+	}
+	return reply.Status, reply.Reason, nil
+}
+
+// GetCTxStatus returns status of C-Chain atomic tx
+func (c *Client) GetCTxStatus(ctx context.Context, txID ids.ID) (evm.Status, error) {
+	var txStatus evm.Status
+	if err := c.client.ReadC(ctx, func(ctx context.Context, cClient evm.Client) error {
+		var err error
+		txStatus, err = cClient.GetAtomicTxStatus(ctx, txID)
+		return err
+	}); err != nil {
+		c.logger.Error(err)
+		return evm.Unknown, err
+	}
+	return txStatus, nil
+}
+
+// WaitForPTx polls node until P-Chain tx is decided, returns error if it wasn't committed
+func (c *Client) WaitForPTx(ctx context.Context, txID ids.ID) error {
+	c.logger.Infof("Waiting for P-Chain tx %s...", txID)
+	ticker := time.NewTicker(txPollInterval)
+	defer ticker.Stop()
+	for {
+		txStatus, reason, err := c.GetPTxStatus(ctx, txID)
+		if err != nil {
+			return err
+		}
+
+		switch txStatus {
+		case status.Committed:
+			return nil
+		case status.Aborted, status.Dropped:
+			err := errkind.Rejected(reason, fmt.Errorf("%w: tx %s is %s: %s", errPTxNotCommitted, txID, txStatus, reason))
+			c.logger.Error(err)
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			c.logger.Error(ctx.Err())
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForCTx polls node until C-Chain atomic tx is decided, returns error if it wasn't accepted
+func (c *Client) WaitForCTx(ctx context.Context, txID ids.ID) error {
+	c.logger.Infof("Waiting for C-Chain tx %s...", txID)
+	ticker := time.NewTicker(txPollInterval)
+	defer ticker.Stop()
+	for {
+		txStatus, err := c.GetCTxStatus(ctx, txID)
+		if err != nil {
+			return err
+		}
+
+		switch txStatus {
+		case evm.Accepted:
+			return nil
+		case evm.Dropped:
+			err := errkind.Rejected(txStatus.String(), fmt.Errorf("%w: tx %s is %s", errCTxNotAccepted, txID, txStatus))
+			c.logger.Error(err)
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			c.logger.Error(ctx.Err())
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package node
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	pLocked "github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/plugin/evm"
+)
+
+// TxResult describes built and signed tx
+type TxResult struct {
+	Type      string            `json:"type"`
+	Chain     string            `json:"chain"`
+	TxID      ids.ID            `json:"txID"`
+	TxHex     string            `json:"txHex"`
+	Fee       uint64            `json:"fee"`
+	Consumed  []string          `json:"consumedUTXOs"`
+	Produced  []*TxOutput       `json:"producedOutputs"`
+	Artifacts map[string]string `json:"artifacts,omitempty"`
+	// MissingSigners of partially signed tx, it can't be issued until they sign it
+	MissingSigners []string `json:"missingSigners,omitempty"`
+}
+
+// TxOutput is output produced by tx
+type TxOutput struct {
+	AssetID   ids.ID   `json:"assetID"`
+	Amount    uint64   `json:"amount"`
+	Threshold uint32   `json:"threshold"`
+	Locktime  uint64   `json:"locktime,omitempty"`
+	Owners    []string `json:"owners"`
+	Locked    bool     `json:"locked,omitempty"`
+}
+
+// PTxResult is built and signed P-Chain tx
+type PTxResult struct {
+	TxResult
+	Tx *pTxs.Tx `json:"-"`
+
+	signers [][]ids.ShortID // addresses of signers of every credential
+}
+
+// CTxResult is built and signed C-Chain atomic tx
+type CTxResult struct {
+	TxResult
+	Tx *evm.Tx `json:"-"`
+}
+
+func (c *Client) newPTxResult(txType string, tx *pTxs.Tx, ins []*avax.TransferableInput, outs []*avax.TransferableOutput) (*PTxResult, error) {
+	txHex, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	c.logger.Debug(txHex)
+	c.logger.Infof("txID: %s", tx.ID())
+
+	result := &PTxResult{
+		TxResult: TxResult{
+			Type:     txType,
+			Chain:    "P",
+			TxID:     tx.ID(),
+			TxHex:    txHex,
+			Consumed: make([]string, len(ins)),
+		},
+		Tx: tx,
+	}
+
+	consumed := uint64(0)
+	for i, in := range ins {
+		result.Consumed[i] = in.UTXOID.String()
+		if in.AssetID() == c.avaxAssetID {
+			consumed += in.In.Amount()
+		}
+	}
+	var produced uint64
+	result.Produced, produced, err = c.txOutputs("P", outs)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	result.Fee = consumed - produced
+	return result, nil
+}
+
+func (c *Client) newCTxResult(txType string, tx *evm.Tx, ins []evm.EVMInput, destinationChain string, outs []*avax.TransferableOutput) (*CTxResult, error) {
+	txHex, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	c.logger.Debug(txHex)
+	c.logger.Infof("txID: %s", tx.ID())
+
+	result := &CTxResult{
+		TxResult: TxResult{
+			Type:     txType,
+			Chain:    "C",
+			TxID:     tx.ID(),
+			TxHex:    txHex,
+			Consumed: make([]string, len(ins)),
+		},
+		Tx: tx,
+	}
+
+	consumed := uint64(0)
+	for i, in := range ins {
+		result.Consumed[i] = in.Address.Hex()
+		if in.AssetID == c.avaxAssetID {
+			consumed += in.Amount
+		}
+	}
+	var produced uint64
+	result.Produced, produced, err = c.txOutputs(destinationChain, outs)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	result.Fee = consumed - produced
+	return result, nil
+}
+
+// txOutputs describes outputs and sums their avax amount
+func (c *Client) txOutputs(chain string, outs []*avax.TransferableOutput) ([]*TxOutput, uint64, error) {
+	txOuts := make([]*TxOutput, len(outs))
+	var produced uint64
+	for i, out := range outs {
+		txOut := &TxOutput{
+			AssetID: out.AssetID(),
+			Amount:  out.Out.Amount(),
+		}
+		innerOut := out.Out
+		if lockedOut, ok := innerOut.(*pLocked.Out); ok {
+			txOut.Locked = lockedOut.IsLocked()
+			innerOut = lockedOut.TransferableOut
+		}
+		if transferOut, ok := innerOut.(*secp256k1fx.TransferOutput); ok {
+			txOut.Threshold = transferOut.Threshold
+			txOut.Locktime = transferOut.Locktime
+			txOut.Owners = make([]string, len(transferOut.Addrs))
+			for j, addr := range transferOut.Addrs {
+				ownerAddr, err := address.Format(chain, c.hrp, addr[:])
+				if err != nil {
+					return nil, 0, err
+				}
+				txOut.Owners[j] = ownerAddr
+			}
+		}
+		if txOut.AssetID == c.avaxAssetID {
+			produced += txOut.Amount
+		}
+		txOuts[i] = txOut
+	}
+	return txOuts, produced, nil
+}
@@ -0,0 +1,290 @@
+package node
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TxSummary is human-readable description of signed tx, that is shown before it's issued
+type TxSummary struct {
+	Network string `json:"network"`
+	Chain   string `json:"chain"`
+	Type    string `json:"type"`
+	TxID    ids.ID `json:"txID"`
+	// From are addresses of tx signers
+	From []string `json:"from"`
+	// To are outputs, that aren't owned only by signers, and Amount is their nCAM sum
+	To     []*TxOutput `json:"to"`
+	Amount uint64      `json:"amount"`
+	Fee    uint64      `json:"fee"`
+	// Details of role changes, proposals, votes, aliases and cross-chain transfers
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// IsProductionNetwork returns true, if client is connected to camino mainnet
+func (c *Client) IsProductionNetwork() bool {
+	return c.networkID == constants.CaminoID
+}
+
+// SummarizePTx describes signed P-Chain tx
+func (c *Client) SummarizePTx(txBytes []byte) (*TxSummary, error) {
+	tx, err := pTxs.Parse(pTxs.Codec, txBytes)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	signers, err := recoverTxSigners(tx.Unsigned.Bytes(), tx.Creds)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	summary := &TxSummary{
+		Network: constants.NetworkName(c.networkID),
+		Chain:   "P",
+		Type:    txTypeName(tx.Unsigned),
+		TxID:    tx.ID(),
+		Details: map[string]string{},
+	}
+	signerAddrs := make(map[ids.ShortID]struct{}, len(signers))
+	for _, signer := range signers {
+		signerAddrs[signer.Address()] = struct{}{}
+		if err := c.appendAddr(&summary.From, "P", signer.Address()); err != nil {
+			return nil, err
+		}
+	}
+
+	var ins []*avax.TransferableInput
+	outs := tx.Unsigned.Outputs()
+	switch utx := tx.Unsigned.(type) {
+	case *pTxs.BaseTx:
+		ins = utx.Ins
+	case *pTxs.ExportTx:
+		ins = utx.Ins
+		outs = append(append([]*avax.TransferableOutput{}, outs...), utx.ExportedOutputs...)
+		summary.Details["destinationChain"] = c.chainName(utx.DestinationChain)
+	case *pTxs.ImportTx:
+		ins = append(append([]*avax.TransferableInput{}, utx.Ins...), utx.ImportedInputs...)
+		summary.Details["sourceChain"] = c.chainName(utx.SourceChain)
+	case *pTxs.AddressStateTx:
+		ins = utx.Ins
+		err = c.addressStateDetails(summary.Details, utx)
+	case *pTxs.AddProposalTx:
+		ins = utx.Ins
+		err = c.proposalDetails(summary.Details, utx)
+	case *pTxs.AddVoteTx:
+		ins = utx.Ins
+		err = c.voteDetails(summary.Details, utx)
+	case *pTxs.MultisigAliasTx:
+		ins = utx.Ins
+		err = c.msigAliasDetails(summary.Details, utx)
+	}
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+
+	consumed := uint64(0)
+	for _, in := range ins {
+		if in.AssetID() == c.avaxAssetID {
+			consumed += in.In.Amount()
+		}
+	}
+	produced := uint64(0)
+	for _, out := range outs {
+		if out.AssetID() == c.avaxAssetID {
+			produced += out.Out.Amount()
+		}
+	}
+	if ins != nil && consumed >= produced {
+		summary.Fee = consumed - produced
+	}
+	if summary.To, summary.Amount, err = c.txOutputs("P", c.sentOutputs(outs, signerAddrs)); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	return summary, nil
+}
+
+// SummarizeCTx describes signed C-Chain atomic tx
+func (c *Client) SummarizeCTx(txBytes []byte) (*TxSummary, error) {
+	tx := &evm.Tx{}
+	if _, err := evm.Codec.Unmarshal(txBytes, tx); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	// signing without signers only initializes tx bytes
+	if err := tx.Sign(evm.Codec, nil); err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	signers, err := recoverTxSigners(tx.UnsignedAtomicTx.Bytes(), tx.Creds)
+	if err != nil {
+		c.logger.Error(err)
+		return nil, err
+	}
+	summary := &TxSummary{
+		Network: constants.NetworkName(c.networkID),
+		Chain:   "C",
+		Type:    txTypeName(tx.UnsignedAtomicTx),
+		TxID:    tx.ID(),
+		Details: map[string]string{},
+	}
+	signerAddrs := make(map[ids.ShortID]struct{}, len(signers))
+	for _, signer := range signers {
+		signerAddrs[signer.Address()] = struct{}{}
+		summary.From = append(summary.From, crypto.PubkeyToAddress(*signer.ToECDSA()).Hex())
+	}
+
+	consumed, produced := uint64(0), uint64(0)
+	switch utx := tx.UnsignedAtomicTx.(type) {
+	case *evm.UnsignedExportTx:
+		for _, in := range utx.Ins {
+			if in.AssetID == c.avaxAssetID {
+				consumed += in.Amount
+			}
+		}
+		for _, out := range utx.ExportedOutputs {
+			if out.AssetID() == c.avaxAssetID {
+				produced += out.Out.Amount()
+			}
+		}
+		destinationChain := c.chainName(utx.DestinationChain)
+		summary.Details["destinationChain"] = destinationChain
+		sentOuts := c.sentOutputs(utx.ExportedOutputs, signerAddrs)
+		if summary.To, summary.Amount, err = c.txOutputs(destinationChain, sentOuts); err != nil {
+			c.logger.Error(err)
+			return nil, err
+		}
+	case *evm.UnsignedImportTx:
+		for _, in := range utx.ImportedInputs {
+			if in.AssetID() == c.avaxAssetID {
+				consumed += in.In.Amount()
+			}
+		}
+		for _, out := range utx.Outs {
+			if out.AssetID != c.avaxAssetID {
+				continue
+			}
+			produced += out.Amount
+			summary.To = append(summary.To, &TxOutput{
+				AssetID:   out.AssetID,
+				Amount:    out.Amount,
+				Threshold: 1,
+				Owners:    []string{out.Address.Hex()},
+			})
+			summary.Amount += out.Amount
+		}
+		summary.Details["sourceChain"] = c.chainName(utx.SourceChain)
+	}
+	if consumed >= produced {
+		summary.Fee = consumed - produced
+	}
+	return summary, nil
+}
+
+func (c *Client) addressStateDetails(details map[string]string, utx *pTxs.AddressStateTx) error {
+	details["action"] = "set"
+	if utx.Remove {
+		details["action"] = "remove"
+	}
+	details["state"] = strconv.FormatUint(uint64(utx.State), 10)
+	var err error
+	if details["address"], err = address.Format("P", c.hrp, utx.Address[:]); err != nil {
+		return err
+	}
+	details["executor"], err = address.Format("P", c.hrp, utx.Executor[:])
+	return err
+}
+
+func (c *Client) proposalDetails(details map[string]string, utx *pTxs.AddProposalTx) error {
+	wrapper := &pTxs.ProposalWrapper{}
+	if _, err := pTxs.Codec.Unmarshal(utx.ProposalPayload, wrapper); err != nil {
+		return err
+	}
+	proposalJSON, err := json.Marshal(wrapper.Proposal)
+	if err != nil {
+		return err
+	}
+	details["proposalKind"], err = proposalKind(utx.ProposalPayload)
+	if err != nil {
+		return err
+	}
+	details["proposal"] = string(proposalJSON)
+	details["proposer"], err = address.Format("P", c.hrp, utx.ProposerAddress[:])
+	return err
+}
+
+func (c *Client) voteDetails(details map[string]string, utx *pTxs.AddVoteTx) error {
+	vote := &pTxs.VoteWrapper{}
+	if _, err := pTxs.Codec.Unmarshal(utx.VotePayload, vote); err != nil {
+		return err
+	}
+	voteJSON, err := json.Marshal(vote.Vote)
+	if err != nil {
+		return err
+	}
+	details["proposalID"] = utx.ProposalID.String()
+	details["vote"] = string(voteJSON)
+	details["voter"], err = address.Format("P", c.hrp, utx.VoterAddress[:])
+	return err
+}
+
+func (c *Client) msigAliasDetails(details map[string]string, utx *pTxs.MultisigAliasTx) error {
+	details["alias"] = "new"
+	if utx.MultisigAlias.ID != ids.ShortEmpty {
+		aliasAddr, err := address.Format("P", c.hrp, utx.MultisigAlias.ID[:])
+		if err != nil {
+			return err
+		}
+		details["alias"] = aliasAddr
+	}
+	owners, ok := utx.MultisigAlias.Owners.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil
+	}
+	details["threshold"] = strconv.FormatUint(uint64(owners.Threshold), 10)
+	ownerAddrs := []string{}
+	for _, owner := range owners.Addrs {
+		if err := c.appendAddr(&ownerAddrs, "P", owner); err != nil {
+			return err
+		}
+	}
+	ownersJSON, err := json.Marshal(ownerAddrs)
+	if err != nil {
+		return err
+	}
+	details["owners"] = string(ownersJSON)
+	return nil
+}
+
+func (c *Client) appendAddr(addrs *[]string, chain string, addr ids.ShortID) error {
+	addrStr, err := address.Format(chain, c.hrp, addr[:])
+	if err != nil {
+		c.logger.Error(err)
+		return err
+	}
+	*addrs = append(*addrs, addrStr)
+	return nil
+}
+
+// chainName returns P, C or X for chain id, it's reverse of getChainID
+func (c *Client) chainName(chainID ids.ID) string {
+	switch chainID {
+	case c.cChainID:
+		return "C"
+	case c.pChainID:
+		return "P"
+	case c.xChainID:
+		return "X"
+	}
+	return chainID.String()
+}
@@ -0,0 +1,282 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	pLocked "github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errInsufficientPoolFunds = errkind.New(errkind.InsufficientFunds, "insufficient funds in utxo pool")
+	errPoolOwnerMismatch     = errkind.New(errkind.InvalidInput, "utxo pool belongs to another key")
+	errPoolCantLock          = errkind.New(errkind.InvalidInput, "utxo pool can't be used for txs that lock funds")
+)
+
+// PUTXOPool is set of unlocked P-Chain avax utxos owned by single key, which is spent locally:
+// change output of every tx built with pool is added back to it. This way txs can be built
+// one after another without waiting for acceptance of previous ones, but each tx
+// must be issued only after tx, which change it spends, is committed.
+type PUTXOPool struct {
+	lock        sync.Mutex
+	owner       ids.ShortID
+	avaxAssetID ids.ID
+	utxos       []*avax.UTXO
+	// pool utxo input id -> id of tx that produced it
+	parents map[ids.ID]ids.ID
+}
+
+// PSpendOption changes how funds for P-Chain tx are spent
+type PSpendOption func(*pSpendOptions)
+
+type pSpendOptions struct {
+	pool *PUTXOPool
+}
+
+// WithUTXOPool makes tx spend utxos from pool instead of utxos selected by node
+func WithUTXOPool(pool *PUTXOPool) PSpendOption {
+	return func(opts *pSpendOptions) {
+		opts.pool = pool
+	}
+}
+
+// NewPUTXOPool creates pool with all unlocked avax utxos of key
+func (c *Client) NewPUTXOPool(ctx context.Context, key Signer) (*PUTXOPool, error) {
+	owner := key.Address()
+	utxos, err := c.getPUTXOs(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	pool := &PUTXOPool{
+		owner:       owner,
+		avaxAssetID: c.avaxAssetID,
+		parents:     map[ids.ID]ids.ID{},
+	}
+	now := uint64(time.Now().Unix())
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok || utxo.AssetID() != c.avaxAssetID || out.Locktime > now ||
+			out.Threshold != 1 || len(out.Addrs) != 1 || out.Addrs[0] != owner {
+			continue
+		}
+		pool.utxos = append(pool.utxos, utxo)
+	}
+	c.logger.Debugf("utxo pool: %d utxos, %d total", len(pool.utxos), pool.Balance())
+	return pool, nil
+}
+
+// Split distributes pool utxos between at most n new pools, so they can be spent independently.
+// Pool must not be used after split.
+func (p *PUTXOPool) Split(n int) []*PUTXOPool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if n > len(p.utxos) {
+		n = len(p.utxos)
+	}
+	if n < 1 {
+		n = 1
+	}
+	sort.Slice(p.utxos, func(i, j int) bool {
+		return p.utxos[i].Out.(*secp256k1fx.TransferOutput).Amt > p.utxos[j].Out.(*secp256k1fx.TransferOutput).Amt
+	})
+	pools := make([]*PUTXOPool, n)
+	for i := range pools {
+		pools[i] = &PUTXOPool{
+			owner:       p.owner,
+			avaxAssetID: p.avaxAssetID,
+			parents:     map[ids.ID]ids.ID{},
+		}
+	}
+	for i, utxo := range p.utxos {
+		pools[i%n].utxos = append(pools[i%n].utxos, utxo)
+	}
+	p.utxos = nil
+	return pools
+}
+
+// Balance returns sum of pool utxos amounts
+func (p *PUTXOPool) Balance() uint64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	balance := uint64(0)
+	for _, utxo := range p.utxos {
+		balance += utxo.Out.(*secp256k1fx.TransferOutput).Amt
+	}
+	return balance
+}
+
+// Parents returns ids of txs built with pool, which outputs are spent by tx.
+// Tx can be issued only after its parents are committed.
+func (p *PUTXOPool) Parents(tx *pTxs.Tx) []ids.ID {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var parents []ids.ID
+	for inputID := range tx.Unsigned.InputIDs() {
+		if parentID, ok := p.parents[inputID]; ok {
+			parents = append(parents, parentID)
+		}
+	}
+	return parents
+}
+
+// pSpending is inputs and outputs of tx that is being built
+type pSpending struct {
+	ins  []*avax.TransferableInput
+	outs []*avax.TransferableOutput
+
+	pool     *PUTXOPool
+	utxos    []*avax.UTXO // consumed pool utxos
+	change   *avax.TransferableOutput
+	released bool
+}
+
+// spendP returns inputs and outputs, that burn and lock given amounts from fundsKey unlocked utxos
+// and return change to it. Inputs are sorted, their signers are fundsKey.
+// Returned spending must be committed with built tx or released, if tx wasn't built.
+func (c *Client) spendP(
+	ctx context.Context,
+	fundsKey Signer,
+	amountToLock uint64,
+	amountToBurn uint64,
+	opts ...PSpendOption,
+) (*pSpending, [][]Signer, error) {
+	options := &pSpendOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	spending := &pSpending{}
+	if options.pool == nil {
+		ins, outs, err := c.client.SpendP(
+			ctx,
+			c.networkID,
+			fundsKey.Address(),
+			fundsKey.Address(),
+			amountToLock, amountToBurn,
+			pLocked.StateUnlocked,
+		)
+		if err != nil {
+			c.logger.Error(err)
+			return nil, nil, err
+		}
+		spending.ins, spending.outs = ins, outs
+	} else {
+		if amountToLock > 0 {
+			c.logger.Error(errPoolCantLock)
+			return nil, nil, errPoolCantLock
+		}
+		if options.pool.owner != fundsKey.Address() {
+			c.logger.Error(errPoolOwnerMismatch)
+			return nil, nil, errPoolOwnerMismatch
+		}
+		if err := options.pool.spend(spending, amountToBurn); err != nil {
+			c.logger.Error(err)
+			return nil, nil, err
+		}
+	}
+
+	// every input is signed by fundsKey alone, so signers don't have to be sorted with inputs
+	avax.SortTransferableInputs(spending.ins)
+	avax.SortTransferableOutputs(spending.outs, pTxs.Codec)
+	signers := make([][]Signer, len(spending.ins))
+	for i := range signers {
+		signers[i] = []Signer{fundsKey}
+	}
+	return spending, signers, nil
+}
+
+// spend takes largest utxos from pool, until they cover amount
+func (p *PUTXOPool) spend(spending *pSpending, amount uint64) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	sort.Slice(p.utxos, func(i, j int) bool {
+		return p.utxos[i].Out.(*secp256k1fx.TransferOutput).Amt > p.utxos[j].Out.(*secp256k1fx.TransferOutput).Amt
+	})
+	consumed := uint64(0)
+	count := 0
+	for ; count < len(p.utxos) && consumed < amount; count++ {
+		utxo := p.utxos[count]
+		out := utxo.Out.(*secp256k1fx.TransferOutput)
+		spending.ins = append(spending.ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  utxo.Asset,
+			In: &secp256k1fx.TransferInput{
+				Amt:   out.Amt,
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		})
+		consumed += out.Amt
+	}
+	if consumed < amount {
+		spending.ins = nil
+		return fmt.Errorf("%w: has %d, needs %d", errInsufficientPoolFunds, consumed, amount)
+	}
+
+	if consumed > amount {
+		spending.change = &avax.TransferableOutput{
+			Asset: avax.Asset{ID: p.avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: consumed - amount,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{p.owner},
+				},
+			},
+		}
+		spending.outs = append(spending.outs, spending.change)
+	}
+	spending.pool = p
+	spending.utxos = append(spending.utxos, p.utxos[:count]...)
+	p.utxos = p.utxos[count:]
+	return nil
+}
+
+// commit adds change output of built tx to pool
+func (s *pSpending) commit(tx *pTxs.Tx) {
+	if s.pool == nil || s.released {
+		return
+	}
+	s.released = true
+	if s.change == nil {
+		return
+	}
+
+	s.pool.lock.Lock()
+	defer s.pool.lock.Unlock()
+	txID := tx.ID()
+	for i, out := range tx.Unsigned.Outputs() {
+		if out != s.change {
+			continue
+		}
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: txID, OutputIndex: uint32(i)},
+			Asset:  out.Asset,
+			Out:    out.Out,
+		}
+		s.pool.utxos = append(s.pool.utxos, utxo)
+		s.pool.parents[utxo.InputID()] = txID
+		return
+	}
+}
+
+// release returns consumed utxos back to pool, if tx wasn't built
+func (s *pSpending) release() {
+	if s.pool == nil || s.released {
+		return
+	}
+	s.released = true
+	s.pool.lock.Lock()
+	defer s.pool.lock.Unlock()
+	s.pool.utxos = append(s.pool.utxos, s.utxos...)
+}
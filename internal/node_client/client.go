@@ -1,49 +1,218 @@
 package node_client
 
 import (
+	"caminoclient/internal/errkind"
 	"caminoclient/internal/logger"
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/interfaces"
 	"github.com/ava-labs/coreth/plugin/evm"
+	ethrpc "github.com/ava-labs/coreth/rpc"
+	"golang.org/x/time/rate"
 )
 
+var (
+	errNoEndpoints = errkind.New(errkind.InvalidInput, "no node endpoints")
+	errTxRejected  = errors.New("tx rejected")
+)
+
+// Config of node rpc client
+type Config struct {
+	// URIs of nodes of the same network, in order of preference.
+	// If node fails, client switches to the next one.
+	URIs []string
+	// Timeout of single rpc call, zero means no timeout
+	Timeout time.Duration
+	// MaxRetries of failed idempotent calls, zero disables retries
+	MaxRetries int
+	// RetryBackoff is delay before first retry, doubled for each next one
+	RetryBackoff time.Duration
+	// RateLimit of rpc calls per second, zero means no limit
+	RateLimit float64
+}
+
 // newClient returns a Client for interacting with the P Chain endpoint
-func NewClient(uri string, logger logger.Logger) (*Client, error) {
-	ethClient, err := ethclient.Dial(uri)
-	if err != nil {
-		logger.Error(err)
-		return nil, err
+func NewClient(ctx context.Context, cfg Config, logger logger.Logger) (*Client, error) {
+	if len(cfg.URIs) == 0 {
+		logger.Error(errNoEndpoints)
+		return nil, errNoEndpoints
+	}
+
+	endpoints := make([]*endpoint, len(cfg.URIs))
+	for i, uri := range cfg.URIs {
+		ethRPCClient, err := ethrpc.DialContext(ctx, uri+"/ext/bc/C/rpc")
+		if err != nil {
+			err = errkind.Wrap(errkind.RPCUnavailable, err)
+			logger.Error(err)
+			return nil, err
+		}
+		endpoints[i] = &endpoint{
+			uri:        uri,
+			p:          platformvm.NewClient(uri),
+			c:          evm.NewClient(uri, "C"),
+			eth:        ethclient.NewClient(ethRPCClient),
+			ethRPC:     ethRPCClient,
+			pRequester: rpc.NewEndpointRequester(uri + "/ext/P"),
+			health:     rpc.NewEndpointRequester(uri + "/ext/health"),
+		}
+	}
+
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if cfg.RateLimit > 0 {
+		burst := int(cfg.RateLimit)
+		if burst < 1 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(cfg.RateLimit), burst)
 	}
 
 	return &Client{
-		P:          platformvm.NewClient(uri),
-		C:          evm.NewClient(uri, "C"),
-		CETH:       ethClient,
-		pRequester: rpc.NewEndpointRequester(uri + "/ext/P"),
-		logger:     logger,
+		endpoints:    endpoints,
+		limiter:      limiter,
+		logger:       logger,
+		timeout:      cfg.Timeout,
+		maxRetries:   cfg.MaxRetries,
+		retryBackoff: cfg.RetryBackoff,
 	}, nil
 }
 
 // Client implementation for interacting with the P Chain endpoint
 type Client struct {
-	P          platformvm.Client
-	C          evm.Client
-	CETH       ethclient.Client
-	pRequester rpc.EndpointRequester
-	logger     logger.Logger
+	endpointsLock sync.Mutex
+	endpoints     []*endpoint
+	current       int
+
+	limiter      *rate.Limiter
+	logger       logger.Logger
+	timeout      time.Duration
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// withTimeout returns context for single rpc call, limited by configured timeout
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// ReadP calls idempotent P-Chain api, retrying it on failure
+func (c *Client) ReadP(ctx context.Context, call func(context.Context, platformvm.Client) error) error {
+	return c.read(ctx, func(ctx context.Context, e *endpoint) error {
+		return call(ctx, e.p)
+	})
+}
+
+// ReadEVM calls idempotent C-Chain eth api, retrying it on failure
+func (c *Client) ReadEVM(ctx context.Context, call func(context.Context, ethclient.Client) error) error {
+	return c.read(ctx, func(ctx context.Context, e *endpoint) error {
+		return call(ctx, e.eth)
+	})
+}
+
+// ReadEVMRPC calls idempotent C-Chain eth api method, that ethclient doesn't expose, retrying it on failure
+func (c *Client) ReadEVMRPC(ctx context.Context, call func(context.Context, *ethrpc.Client) error) error {
+	return c.read(ctx, func(ctx context.Context, e *endpoint) error {
+		return call(ctx, e.ethRPC)
+	})
+}
+
+// ReadC calls idempotent C-Chain avax api, retrying it on failure
+func (c *Client) ReadC(ctx context.Context, call func(context.Context, evm.Client) error) error {
+	return c.read(ctx, func(ctx context.Context, e *endpoint) error {
+		return call(ctx, e.c)
+	})
+}
+
+// IssuePTx issues signed P-Chain tx. If issuance failed, tx is issued again
+// only if node doesn't know it.
+func (c *Client) IssuePTx(ctx context.Context, txBytes []byte) (ids.ID, error) {
+	txID := ids.ID(hashing.ComputeHash256Array(txBytes))
+	return txID, c.issue(ctx,
+		func(ctx context.Context, e *endpoint) error {
+			_, err := e.p.IssueTx(ctx, txBytes)
+			return err
+		},
+		func(ctx context.Context, e *endpoint) (bool, error) {
+			reply, err := e.p.GetTxStatus(ctx, txID)
+			if err != nil {
+				return false, err
+			}
+			switch reply.Status {
+			case status.Committed, status.Processing:
+				return true, nil
+			case status.Unknown:
+				return false, nil
+			}
+			return false, errkind.Rejected(reply.Reason, fmt.Errorf("%w: tx %s is %s: %s", errTxRejected, txID, reply.Status, reply.Reason))
+		},
+	)
+}
+
+// IssueCTx issues signed C-Chain atomic tx. If issuance failed, tx is issued again
+// only if node doesn't know it.
+func (c *Client) IssueCTx(ctx context.Context, txBytes []byte) (ids.ID, error) {
+	txID := ids.ID(hashing.ComputeHash256Array(txBytes))
+	return txID, c.issue(ctx,
+		func(ctx context.Context, e *endpoint) error {
+			_, err := e.c.IssueTx(ctx, txBytes)
+			return err
+		},
+		func(ctx context.Context, e *endpoint) (bool, error) {
+			txStatus, err := e.c.GetAtomicTxStatus(ctx, txID)
+			if err != nil {
+				return false, err
+			}
+			switch txStatus {
+			case evm.Accepted, evm.Processing:
+				return true, nil
+			case evm.Unknown:
+				return false, nil
+			}
+			return false, errkind.Rejected(txStatus.String(), fmt.Errorf("%w: tx %s is %s", errTxRejected, txID, txStatus))
+		},
+	)
+}
+
+// SendEVMTx sends signed C-Chain eth tx. If sending failed, tx is sent again
+// only if node doesn't know it.
+func (c *Client) SendEVMTx(ctx context.Context, tx *types.Transaction) error {
+	return insufficientFundsError(c.issue(ctx,
+		func(ctx context.Context, e *endpoint) error {
+			return e.eth.SendTransaction(ctx, tx)
+		},
+		func(ctx context.Context, e *endpoint) (bool, error) {
+			_, _, err := e.eth.TransactionByHash(ctx, tx.Hash())
+			switch {
+			case err == nil:
+				return true, nil
+			case errors.Is(err, interfaces.NotFound):
+				return false, nil
+			}
+			return false, err
+		},
+	))
 }
 
 // TODO update caminogo p-spend
@@ -74,19 +243,22 @@ func (c *Client) SpendP(
 		BaseTx string `json:"baseTx"`
 	}
 	res := &Spend2Reply{}
-	if err := c.pRequester.SendRequest(ctx, "platform.spend2", &platformvm.SpendArgs{
-		JSONFromAddrs: api.JSONFromAddrs{
-			From: []string{fromAddr},
-		},
-		To: platformapi.Owner{
-			Threshold: 1,
-			Addresses: []string{toAddr},
-		},
-		AmountToLock: json.Uint64(amountToLock),
-		AmountToBurn: json.Uint64(amountToBurn),
-		LockMode:     byte(lockMode),
-		Encoding:     formatting.Hex,
-	}, res, options...); err != nil {
+	if err := c.read(ctx, func(ctx context.Context, e *endpoint) error {
+		return e.pRequester.SendRequest(ctx, "platform.spend2", &platformvm.SpendArgs{
+			JSONFromAddrs: api.JSONFromAddrs{
+				From: []string{fromAddr},
+			},
+			To: platformapi.Owner{
+				Threshold: 1,
+				Addresses: []string{toAddr},
+			},
+			AmountToLock: json.Uint64(amountToLock),
+			AmountToBurn: json.Uint64(amountToBurn),
+			LockMode:     byte(lockMode),
+			Encoding:     formatting.Hex,
+		}, res, options...)
+	}); err != nil {
+		err = insufficientFundsError(err)
 		c.logger.Error(err)
 		return nil, nil, err
 	}
@@ -104,3 +276,28 @@ func (c *Client) SpendP(
 
 	return baseTx.Ins, baseTx.Outs, err
 }
+
+type GetMultisigAliasReply struct {
+	Memo      string      `json:"memo"`
+	Locktime  json.Uint64 `json:"locktime"`
+	Threshold json.Uint32 `json:"threshold"`
+	Addresses []string    `json:"addresses"`
+	Nonce     json.Uint64 `json:"nonce"`
+}
+
+// GetMultisigAlias returns definition of multisig alias with given P-Chain address
+func (c *Client) GetMultisigAlias(
+	ctx context.Context,
+	aliasAddr string,
+	options ...rpc.Option,
+) (*GetMultisigAliasReply, error) {
+	res := &GetMultisigAliasReply{}
+	if err := c.read(ctx, func(ctx context.Context, e *endpoint) error {
+		return e.pRequester.SendRequest(ctx, "platform.getMultisigAlias", &api.JSONAddress{
+			Address: aliasAddr,
+		}, res, options...)
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,127 @@
+package node_client
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/coreth/ethclient"
+	"github.com/ava-labs/coreth/plugin/evm"
+	ethrpc "github.com/ava-labs/coreth/rpc"
+)
+
+// failed endpoint isn't used until cooldown passes and it is healthy again
+const endpointCooldown = 30 * time.Second
+
+// endpoint is set of api clients of single node
+type endpoint struct {
+	uri        string
+	p          platformvm.Client
+	c          evm.Client
+	eth        ethclient.Client
+	ethRPC     *ethrpc.Client // raw rpc client of eth, for calls that ethclient doesn't expose
+	pRequester rpc.EndpointRequester
+	health     rpc.EndpointRequester
+
+	failedAt time.Time // zero, if endpoint didn't fail
+}
+
+// endpointCandidate is endpoint, that is used, if it's the first healthy one
+type endpointCandidate struct {
+	index    int
+	failedAt time.Time // zero, if endpoint didn't fail
+	healthy  bool
+}
+
+// endpoint returns current endpoint or, if it failed, first next one that is healthy.
+// If all endpoints failed, current one is returned. Health of failed endpoints is checked
+// without endpointsLock, so concurrent calls don't wait for health checks.
+func (c *Client) endpoint(ctx context.Context) *endpoint {
+	candidates := c.endpointCandidates()
+	checked := 0
+	for i := range candidates {
+		candidate := &candidates[i]
+		candidate.healthy = candidate.failedAt.IsZero() || c.isHealthy(ctx, c.endpoints[candidate.index])
+		checked++
+		if candidate.healthy {
+			break
+		}
+	}
+
+	c.endpointsLock.Lock()
+	defer c.endpointsLock.Unlock()
+
+	var selected *endpointCandidate
+	for i := range candidates[:checked] {
+		candidate := &candidates[i]
+		e := c.endpoints[candidate.index]
+		// endpoint could fail again, while its health was checked
+		if !candidate.failedAt.IsZero() && e.failedAt.Equal(candidate.failedAt) {
+			if candidate.healthy {
+				e.failedAt = time.Time{}
+			} else {
+				e.failedAt = time.Now()
+			}
+		}
+		if candidate.healthy {
+			selected = candidate
+		}
+	}
+	if selected == nil {
+		return c.endpoints[c.current]
+	}
+	if selected.index != c.current {
+		c.logger.Infof("switching to node %s", c.endpoints[selected.index].uri)
+		c.current = selected.index
+	}
+	return c.endpoints[selected.index]
+}
+
+// endpointCandidates returns endpoints starting from current one up to first one that didn't fail,
+// failed endpoints are skipped until their cooldown passes
+func (c *Client) endpointCandidates() []endpointCandidate {
+	c.endpointsLock.Lock()
+	defer c.endpointsLock.Unlock()
+
+	candidates := []endpointCandidate{}
+	for i := 0; i < len(c.endpoints); i++ {
+		index := (c.current + i) % len(c.endpoints)
+		e := c.endpoints[index]
+		if !e.failedAt.IsZero() && time.Since(e.failedAt) < endpointCooldown {
+			continue
+		}
+		candidates = append(candidates, endpointCandidate{index: index, failedAt: e.failedAt})
+		if e.failedAt.IsZero() {
+			break
+		}
+	}
+	return candidates
+}
+
+// markFailed excludes endpoint from use until its cooldown passes
+func (c *Client) markFailed(e *endpoint, err error) {
+	c.endpointsLock.Lock()
+	defer c.endpointsLock.Unlock()
+	if len(c.endpoints) > 1 {
+		c.logger.Infof("node %s failed: %v", e.uri, err)
+	}
+	e.failedAt = time.Now()
+}
+
+// isHealthy checks node health api
+func (c *Client) isHealthy(ctx context.Context, e *endpoint) bool {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	reply := &struct {
+		Healthy bool `json:"healthy"`
+	}{}
+	if err := e.health.SendRequest(ctx, "health.health", struct{}{}, reply); err != nil {
+		c.logger.Debugf("node %s health check failed: %v", e.uri, err)
+		return false
+	}
+	if !reply.Healthy {
+		c.logger.Debugf("node %s is unhealthy", e.uri)
+	}
+	return reply.Healthy
+}
@@ -0,0 +1,190 @@
+package node_client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"caminoclient/internal/errkind"
+
+	ethrpc "github.com/ava-labs/coreth/rpc"
+	"github.com/gorilla/rpc/v2/json2"
+)
+
+const maxRetryBackoff = 10 * time.Second
+
+// node responses with these status codes are worth retrying
+var retryableStatusCodes = []string{
+	"status code: 429",
+	"status code: 502",
+	"status code: 503",
+	"status code: 504",
+}
+
+// read makes idempotent rpc call. If call failed because of network or node
+// unavailability, it is retried with exponential backoff, switching node if possible.
+// Error of last retry is returned as RPCUnavailable.
+func (c *Client) read(ctx context.Context, call func(context.Context, *endpoint) error) error {
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := c.call(ctx, call)
+		if err == nil || !isRetryable(ctx, err) {
+			return err
+		}
+		if attempt >= c.maxRetries {
+			return errkind.Wrap(errkind.RPCUnavailable, err)
+		}
+		c.logger.Debugf("rpc call failed, retry %d/%d in %s: %v", attempt+1, c.maxRetries, backoff, err)
+		if err := sleep(ctx, backoff); err != nil {
+			return err
+		}
+		backoff = nextBackoff(backoff)
+	}
+}
+
+// issue makes non-idempotent rpc call. If call failed, it is repeated only
+// after isIssued reports that node doesn't know issued object.
+// If status can't be checked, original error is returned as RPCUnavailable.
+func (c *Client) issue(
+	ctx context.Context,
+	call func(context.Context, *endpoint) error,
+	isIssued func(context.Context, *endpoint) (bool, error),
+) error {
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := c.call(ctx, call)
+		switch {
+		case err == nil, ctx.Err() != nil:
+			return err
+		case !isRetryable(ctx, err):
+			return issueError(err)
+		case attempt >= c.maxRetries:
+			return errkind.Wrap(errkind.RPCUnavailable, err)
+		}
+		c.logger.Debugf("issuance failed, checking status in %s: %v", backoff, err)
+		if err := sleep(ctx, backoff); err != nil {
+			return err
+		}
+		issued := false
+		if statusErr := c.read(ctx, func(ctx context.Context, e *endpoint) error {
+			var err error
+			issued, err = isIssued(ctx, e)
+			return err
+		}); statusErr != nil {
+			c.logger.Error(statusErr)
+			if errors.Is(statusErr, errkind.TxRejected) {
+				return statusErr
+			}
+			return errkind.Wrap(errkind.RPCUnavailable, err)
+		}
+		if issued {
+			return nil
+		}
+		c.logger.Debugf("issuance failed and node doesn't know it, retry %d/%d", attempt+1, c.maxRetries)
+		backoff = nextBackoff(backoff)
+	}
+}
+
+// call makes single rate-limited rpc call on current endpoint
+func (c *Client) call(ctx context.Context, call func(context.Context, *endpoint) error) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	e := c.endpoint(ctx)
+	rpcCtx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := call(rpcCtx, e)
+	if err != nil && isRetryable(ctx, err) {
+		c.markFailed(e, err)
+	}
+	return err
+}
+
+// isRetryable returns true, if err is caused by network or node unavailability
+// and not by the call itself or cancellation of ctx
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.As(err, &netErr):
+		return true
+	}
+	errStr := err.Error()
+	for _, code := range retryableStatusCodes {
+		if strings.Contains(errStr, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// issueError returns error of issuance, that isn't retried. Error response of node api
+// means that node refused issued object, so it's TxRejected. Other node errors are
+// RPCUnavailable, errors of client itself keep their kind.
+func issueError(err error) error {
+	var apiErr *json2.Error
+	var ethAPIErr ethrpc.Error
+	var httpErr ethrpc.HTTPError
+	switch {
+	case errors.Is(err, errkind.TxRejected):
+		return err
+	case errors.As(err, &apiErr), errors.As(err, &ethAPIErr):
+		return errkind.Rejected(err.Error(), err)
+	case errors.As(err, &httpErr) && httpErr.StatusCode >= http.StatusInternalServerError,
+		strings.Contains(err.Error(), "status code: 5"):
+		return errkind.Wrap(errkind.RPCUnavailable, err)
+	}
+	return err
+}
+
+// node error messages containing these parts are caused by insufficient funds
+var insufficientFundsMessages = []string{
+	"insufficient funds",
+	"insufficient balance",
+	"not enough balance",
+}
+
+// insufficientFundsError returns node error about insufficient funds as InsufficientFunds error
+func insufficientFundsError(err error) error {
+	if err == nil {
+		return nil
+	}
+	errStr := strings.ToLower(err.Error())
+	for _, msg := range insufficientFundsMessages {
+		if strings.Contains(errStr, msg) {
+			return errkind.Wrap(errkind.InsufficientFunds, err)
+		}
+	}
+	return err
+}
+
+func nextBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+	return backoff
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
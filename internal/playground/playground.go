@@ -12,9 +12,9 @@ func NewPlayground(ctx context.Context, logger logger.Logger, cfg *config.Config
 	return &Playground{
 		logger: logger,
 		utils:  utils.NewUtils(logger),
-		// creator: LocalClient(logger),
-		// issuer:  LocalClient(logger),
-		// client:  LocalClient(logger),
+		// creator: LocalClient(ctx, logger),
+		// issuer:  LocalClient(ctx, logger),
+		// client:  LocalClient(ctx, logger),
 	}, nil
 }
 
@@ -35,7 +35,7 @@ func (p *Playground) Run(ctx context.Context) error {
 	// p.logger.Debug(encodedSignature)
 	// tx := p.utils.PTX("0x000000002002000003ea00000000000000000000000000000000000000000000000000000000000000000000000259eb48b8b3a928ca9d6b90a0f3492ab47ebf06e9edc553cfb6bcd2d3f38e319a00000007000000003b8b87c000000000000000000000000100000001312f06ca09361eb1699860f36f5ac0635afe8c5659eb48b8b3a928ca9d6b90a0f3492ab47ebf06e9edc553cfb6bcd2d3f38e319a000020010000000000000000000000000000000000000000000000000000000000000000746869732074782069640000000000000000000000000000000000000000000000000007000001d1a94a200000000000000000000000000100000001312f06ca09361eb1699860f36f5ac0635afe8c5600000001b46a7d30a289585d845ee6a0e98be0764a0933674d7325ec298b6fcaf6b1bfd90000000059eb48b8b3a928ca9d6b90a0f3492ab47ebf06e9edc553cfb6bcd2d3f38e319a00000005000001d1e4d5a7c00000000100000000000000009766dbf732231be13e6913a8e0450b9b068659610000000065607c42000000006561cdb3000001d1a94a2000000000000000000b00000000000000000000000100000001312f06ca09361eb1699860f36f5ac0635afe8c56000000000000000a00000001000000000000000200000009000000010b94da4a088c203fa73ebc89d7fa157a87e62fc9ce1b5255bae59b16a7b33c9162ba3dffbe7a6ae6bbf5413be449df8e6394c96f1d82d4e449aa7f9b541709bf0000000009000000010b94da4a088c203fa73ebc89d7fa157a87e62fc9ce1b5255bae59b16a7b33c9162ba3dffbe7a6ae6bbf5413be449df8e6394c96f1d82d4e449aa7f9b541709bf00da7958fd")
 	// fmt.Println(tx.SyntacticVerify(snow.DefaultContextTest()))
-	// tx, err := p.client.GetPTX(p.utils.ID("2NsoB8oNWJLAWPf629soQf8YkN351C79UbNVBreMTSMG8LC7eb"))
+	// tx, err := p.client.GetPTX(ctx, p.utils.ID("2NsoB8oNWJLAWPf629soQf8YkN351C79UbNVBreMTSMG8LC7eb"))
 	// p.logger.NoError(err)
 	// utx, ok := tx.Unsigned.(*txs.AddProposalTx)
 	// if !ok {
@@ -53,18 +53,19 @@ func (p *Playground) Run(ctx context.Context) error {
 	// ctx1.NetworkID = 1002
 	// tx.SyntacticVerify(ctx1)
 
-	// addressStateTx, err := p.creator.AddressStateTx(evgeniiTestKey.Address(), addrstate.AddressStateBitRoleAdmin, false, localValidator0Key, localValidator0Key)
+	// addressStateTx, err := p.creator.AddressStateTx(ctx, evgeniiTestKey.Address(), addrstate.AddressStateBitRoleAdmin, false, localValidator0Key, localValidator0Key)
 	// p.logger.NoError(err)
-	// p.logger.NoError(p.issuer.IssuePTx(addressStateTx.Bytes()))
+	// p.logger.NoError(p.issuer.IssuePTx(ctx, addressStateTx.Bytes()))
 
 	// time.Sleep(5 * time.Second)
 
-	// _, err := p.creator.ProposalTx(&dac.BaseFeeProposal{
+	// _, err := p.creator.ProposalTx(ctx, &dac.BaseFeeProposal{
 	// 	Start:   uint64(time.Now().Unix()) + 10,
 	// 	End:     uint64(time.Now().Unix()) + 240,
 	// 	Options: []uint64{10},
 	// }, evgeniiTestKey, evgeniiTestKey)
 	// _, err := p.creator.ProposalTx(
+	// 	ctx,
 	// 	&dac.ExcludeMemberProposal{
 	// 		Start:         uint64(time.Now().Unix()) + 10,
 	// 		End:           uint64(time.Now().Unix()) + 10 + dac.ExcludeMemberProposalMinDuration,
@@ -74,6 +75,7 @@ func (p *Playground) Run(ctx context.Context) error {
 	// 	evgeniiTestKey,
 	// )
 	// _, err := p.creator.ProposalTx(
+	// 	ctx,
 	// 	&dac.AddMemberProposal{
 	// 		Start:            uint64(time.Now().Unix()) + 10,
 	// 		End:              uint64(time.Now().Unix()) + 10 + dac.ExcludeMemberProposalMinDuration,
@@ -83,6 +85,7 @@ func (p *Playground) Run(ctx context.Context) error {
 	// 	evgeniiTestKey,
 	// )
 	// proposalTx, err := p.creator.ProposalTx(
+	// 	ctx,
 	// 	&dac.AdminProposal{
 	// 		Proposal: &dac.AddMemberProposal{
 	// 			Start:            uint64(time.Now().Unix()) + 10,
@@ -95,6 +98,7 @@ func (p *Playground) Run(ctx context.Context) error {
 	// 	evgeniiTestKey,
 	// )
 	// proposalTx, err := p.creator.ProposalTx(
+	// 	ctx,
 	// 	&dac.AdminProposal{
 	// 		Proposal: &dac.ExcludeMemberProposal{
 	// 			Start:         uint64(time.Now().Unix()) + 10,
@@ -107,18 +111,18 @@ func (p *Playground) Run(ctx context.Context) error {
 	// 	evgeniiTestKey,
 	// )
 	// p.logger.NoError(err)
-	// p.logger.NoError(p.issuer.IssuePTx(proposalTx.Bytes()))
+	// p.logger.NoError(p.issuer.IssuePTx(ctx, proposalTx.Bytes()))
 
 	// time.Sleep(5 * time.Second)
 
 	// proposalTxID := proposalTx.ID()
 	// proposalTxID := p.utils.ID("mYFYkuzAV6tdGPHMUReNAnTPU5u8yC5ogR4PpYQPPxAa52BmT")
-	// _, err := p.creator.VoteTx(proposalTxID, 0,
+	// _, err := p.creator.VoteTx(ctx, proposalTxID, 0,
 	// 	evgeniiTestKey,
 	// 	evgeniiTestKey,
 	// )
 	// p.logger.NoError(err)
-	// p.logger.NoError(p.issuer.IssuePTx(voteTx.Bytes()))
+	// p.logger.NoError(p.issuer.IssuePTx(ctx, voteTx.Bytes()))
 
 	return nil
 }
@@ -3,6 +3,9 @@ package playground
 import (
 	"caminoclient/internal/logger"
 	"caminoclient/internal/node"
+	"caminoclient/internal/node_client"
+	"context"
+	"time"
 
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 )
@@ -14,6 +17,12 @@ const (
 	// columbusNode   = ""
 	caminoNode = "https://api.camino.network"
 
+	rpcTimeout      = 30 * time.Second
+	rpcRetries      = 3
+	rpcRetryBackoff = 500 * time.Millisecond
+	// public nodes are rate limited
+	publicNodeRateLimit = 10
+
 	caminoFeeKeyStr = "\"PrivateKey-2bMrxpyN24b6BsiTjRDw3h7w7nC75ecZ5vkSyrDksxthvxXQ8o\""
 
 	// 7Sdex3LTEjsnswW38Eb48hQ9insctGrsN : P-kopernikus1g65uqn6t77p656w64023nh8nd9updzmxh8ttv3
@@ -55,36 +64,63 @@ func init() {
 
 // Node
 
-func LocalClient(logger logger.Logger) *node.Client {
+func LocalClient(ctx context.Context, logger logger.Logger) *node.Client {
 	txc, err := node.NewClient(
-		localNode,
+		ctx,
+		node_client.Config{
+			URIs:         []string{localNode},
+			Timeout:      rpcTimeout,
+			MaxRetries:   rpcRetries,
+			RetryBackoff: rpcRetryBackoff,
+		},
 		logger,
 	)
 	logger.NoError(err)
 	return txc
 }
 
-func KopernikusClient(logger logger.Logger) *node.Client {
+func KopernikusClient(ctx context.Context, logger logger.Logger) *node.Client {
 	txc, err := node.NewClient(
-		kopernikusNode,
+		ctx,
+		node_client.Config{
+			URIs:         []string{kopernikusNode},
+			Timeout:      rpcTimeout,
+			MaxRetries:   rpcRetries,
+			RetryBackoff: rpcRetryBackoff,
+			RateLimit:    publicNodeRateLimit,
+		},
 		logger,
 	)
 	logger.NoError(err)
 	return txc
 }
 
-func UnchainedClient(logger logger.Logger) *node.Client {
+func UnchainedClient(ctx context.Context, logger logger.Logger) *node.Client {
 	txc, err := node.NewClient(
-		unchainedNode,
+		ctx,
+		node_client.Config{
+			URIs:         []string{unchainedNode},
+			Timeout:      rpcTimeout,
+			MaxRetries:   rpcRetries,
+			RetryBackoff: rpcRetryBackoff,
+			RateLimit:    publicNodeRateLimit,
+		},
 		logger,
 	)
 	logger.NoError(err)
 	return txc
 }
 
-func CaminoClient(logger logger.Logger) *node.Client {
+func CaminoClient(ctx context.Context, logger logger.Logger) *node.Client {
 	txc, err := node.NewClient(
-		caminoNode,
+		ctx,
+		node_client.Config{
+			URIs:         []string{caminoNode},
+			Timeout:      rpcTimeout,
+			MaxRetries:   rpcRetries,
+			RetryBackoff: rpcRetryBackoff,
+			RateLimit:    publicNodeRateLimit,
+		},
 		logger,
 	)
 	logger.NoError(err)
@@ -0,0 +1,397 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"caminoclient/internal/utils"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// Denial rules, that reasons refer to
+const (
+	RuleNetwork     = "network"
+	RuleKey         = "key"
+	RuleTxType      = "txType"
+	RuleMaxAmount   = "maxAmount"
+	RuleWindow      = "maxWindowAmount"
+	RuleDestination = "destination"
+	RuleProposal    = "proposal"
+	RuleVote        = "vote"
+)
+
+// ErrDenied is wrapped by Denial
+var ErrDenied = errors.New("signing denied by policy")
+
+// Request describes tx, which signing is authorized
+type Request struct {
+	NetworkID uint32
+	Chain     string // P or C
+	TxType    string
+	// Keys are addresses of keys, that sign tx
+	Keys []ids.ShortID
+	// Amount is nCAM, that tx sends to Destinations, change and keys own outputs excluded
+	Amount       uint64
+	Destinations []Destination
+	// ProposalKind is kind of proposal, that tx adds or votes on
+	ProposalKind string
+}
+
+// Reason is why signing was denied
+type Reason struct {
+	Key     string `json:"key,omitempty"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Denial is error with reasons of denied signing
+type Denial struct {
+	Reasons []*Reason `json:"reasons"`
+}
+
+func (d *Denial) Error() string {
+	messages := make([]string, len(d.Reasons))
+	for i, reason := range d.Reasons {
+		messages[i] = reason.Message
+		if reason.Key != "" {
+			messages[i] = reason.Key + ": " + reason.Message
+		}
+	}
+	return fmt.Sprintf("%s: %s", ErrDenied, strings.Join(messages, "; "))
+}
+
+func (d *Denial) Unwrap() error {
+	return ErrDenied
+}
+
+// Engine authorizes signing requests with policy and tracks amounts signed in rolling windows
+type Engine struct {
+	networks    map[uint32]struct{}
+	rules       map[ids.ShortID]*rule
+	defaultRule *rule
+	statePath   string
+
+	lock    sync.Mutex
+	windows map[string][]*signedAmount // by key address
+	now     func() time.Time
+}
+
+type rule struct {
+	*Rule
+	destinations map[Destination]struct{}
+}
+
+// signedAmount is amount sent by tx signed at time
+type signedAmount struct {
+	Time   time.Time `json:"time"`
+	Amount uint64    `json:"amount"`
+
+	reserved bool // amount isn't committed yet, it isn't saved to state file
+}
+
+// Reservation holds amount of authorized request in rolling windows of its keys, so concurrent
+// requests can't exceed them. It must be committed, once tx is issued, or released otherwise.
+// Only committed amounts are saved to policy state file. Nil reservation does nothing.
+type Reservation struct {
+	engine  *Engine
+	amounts map[string]*signedAmount // by key address
+}
+
+// NewEngine creates engine with policy, loading rolling windows from policy state file, if it exists
+func NewEngine(policy *Policy) (*Engine, error) {
+	e := &Engine{
+		networks:  make(map[uint32]struct{}, len(policy.Networks)),
+		rules:     make(map[ids.ShortID]*rule, len(policy.Keys)),
+		statePath: policy.StatePath,
+		windows:   map[string][]*signedAmount{},
+		now:       time.Now,
+	}
+	for _, networkID := range policy.Networks {
+		e.networks[networkID] = struct{}{}
+	}
+	for keyStr, keyRule := range policy.Keys {
+		keyAddr, err := parseKeyAddress(keyStr)
+		if err != nil {
+			return nil, err
+		}
+		if e.rules[keyAddr], err = newRule(keyRule); err != nil {
+			return nil, fmt.Errorf("%s: %w", keyStr, err)
+		}
+	}
+	if policy.Default != nil {
+		var err error
+		if e.defaultRule, err = newRule(policy.Default); err != nil {
+			return nil, fmt.Errorf("default: %w", err)
+		}
+	}
+	if err := e.loadWindows(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// LoadEngine creates engine with policy from json file
+func LoadEngine(path string) (*Engine, error) {
+	policy, err := ReadPolicy(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewEngine(policy)
+}
+
+func newRule(r *Rule) (*rule, error) {
+	if r.MaxWindowAmount > 0 && r.Window <= 0 {
+		return nil, errNoWindow
+	}
+	compiled := &rule{Rule: r, destinations: make(map[Destination]struct{}, len(r.Destinations))}
+	for _, addrStr := range r.Destinations {
+		destination, err := parseDestination(addrStr)
+		if err != nil {
+			return nil, err
+		}
+		compiled.destinations[destination] = struct{}{}
+	}
+	return compiled, nil
+}
+
+// Authorize returns Denial with reasons, if any key of request isn't allowed to sign it.
+// Otherwise request amount is reserved in rolling windows of its keys.
+func (e *Engine) Authorize(req *Request) (*Reservation, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	now := e.now()
+	denial := &Denial{}
+	if _, ok := e.networks[req.NetworkID]; len(e.networks) > 0 && !ok {
+		denial.Reasons = append(denial.Reasons, &Reason{
+			Rule:    RuleNetwork,
+			Message: fmt.Sprintf("network %d isn't allowed", req.NetworkID),
+		})
+	}
+	keys := uniqueKeys(req.Keys)
+	for _, keyAddr := range keys {
+		denial.Reasons = append(denial.Reasons, e.keyReasons(keyAddr, req, now)...)
+	}
+	if len(denial.Reasons) > 0 {
+		return nil, denial
+	}
+	return e.reserve(keys, req.Amount, now), nil
+}
+
+// Record adds request amount to rolling windows of its keys without authorizing it.
+// It's used for issued txs, which signing wasn't authorized by this engine.
+func (e *Engine) Record(req *Request) error {
+	e.lock.Lock()
+	reservation := e.reserve(uniqueKeys(req.Keys), req.Amount, e.now())
+	e.lock.Unlock()
+	return reservation.Commit()
+}
+
+// reserve adds amount to windows of keys, which rules have them
+func (e *Engine) reserve(keys []ids.ShortID, amount uint64, now time.Time) *Reservation {
+	reservation := &Reservation{engine: e, amounts: map[string]*signedAmount{}}
+	if amount == 0 {
+		return reservation
+	}
+	for _, keyAddr := range keys {
+		if r := e.rule(keyAddr); r != nil && r.Window > 0 {
+			key := keyAddr.String()
+			signed := &signedAmount{Time: now, Amount: amount, reserved: true}
+			e.windows[key] = append(e.windows[key], signed)
+			reservation.amounts[key] = signed
+		}
+	}
+	return reservation
+}
+
+// Commit makes reserved amounts permanent and saves them to policy state file
+func (r *Reservation) Commit() error {
+	if r == nil {
+		return nil
+	}
+	e := r.engine
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if len(r.amounts) == 0 {
+		return nil
+	}
+	for _, amount := range r.amounts {
+		amount.reserved = false
+	}
+	r.amounts = nil
+	return e.saveWindows()
+}
+
+// Release removes reserved amounts from rolling windows
+func (r *Reservation) Release() {
+	if r == nil {
+		return
+	}
+	e := r.engine
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for key, reserved := range r.amounts {
+		amounts := e.windows[key]
+		for i, amount := range amounts {
+			if amount == reserved {
+				e.windows[key] = append(amounts[:i:i], amounts[i+1:]...)
+				break
+			}
+		}
+	}
+	r.amounts = nil
+}
+
+func (e *Engine) rule(keyAddr ids.ShortID) *rule {
+	if r, ok := e.rules[keyAddr]; ok {
+		return r
+	}
+	return e.defaultRule
+}
+
+func (e *Engine) keyReasons(keyAddr ids.ShortID, req *Request, now time.Time) []*Reason {
+	key := keyAddr.String()
+	r := e.rule(keyAddr)
+	if r == nil {
+		return []*Reason{{Key: key, Rule: RuleKey, Message: "key has no policy rule"}}
+	}
+
+	reasons := []*Reason{}
+	if !allows(r.TxTypes, req.TxType, req.Chain+"."+req.TxType) {
+		reasons = append(reasons, &Reason{
+			Key:     key,
+			Rule:    RuleTxType,
+			Message: fmt.Sprintf("%s.%s isn't allowed", req.Chain, req.TxType),
+		})
+	}
+	if r.MaxAmount > 0 && req.Amount > r.MaxAmount {
+		reasons = append(reasons, &Reason{
+			Key:     key,
+			Rule:    RuleMaxAmount,
+			Message: fmt.Sprintf("amount %d exceeds max %d", req.Amount, r.MaxAmount),
+		})
+	}
+	if r.MaxWindowAmount > 0 {
+		windowAmount := e.windowAmount(keyAddr, time.Duration(r.Window), now)
+		if windowAmount+req.Amount > r.MaxWindowAmount || windowAmount+req.Amount < windowAmount {
+			reasons = append(reasons, &Reason{
+				Key:  key,
+				Rule: RuleWindow,
+				Message: fmt.Sprintf("amount %d with %d signed during last %s exceeds max %d",
+					req.Amount, windowAmount, time.Duration(r.Window), r.MaxWindowAmount),
+			})
+		}
+	}
+	if len(r.destinations) > 0 {
+		for _, destination := range req.Destinations {
+			if _, ok := r.destinations[destination]; !ok {
+				reasons = append(reasons, &Reason{
+					Key:     key,
+					Rule:    RuleDestination,
+					Message: fmt.Sprintf("destination %s isn't allowed", destination),
+				})
+			}
+		}
+	}
+	if req.ProposalKind != "" {
+		kinds, ruleName, action := r.Proposals, RuleProposal, "submitting"
+		if req.TxType == "AddVoteTx" {
+			kinds, ruleName, action = r.Votes, RuleVote, "voting on"
+		}
+		if !allows(kinds, req.ProposalKind) {
+			reasons = append(reasons, &Reason{
+				Key:     key,
+				Rule:    ruleName,
+				Message: fmt.Sprintf("%s %s proposals isn't allowed", action, req.ProposalKind),
+			})
+		}
+	}
+	return reasons
+}
+
+// windowAmount prunes amounts signed before window and sums the rest
+func (e *Engine) windowAmount(keyAddr ids.ShortID, window time.Duration, now time.Time) uint64 {
+	key := keyAddr.String()
+	amounts := e.windows[key]
+	start := now.Add(-window)
+	for len(amounts) > 0 && !amounts[0].Time.After(start) {
+		amounts = amounts[1:]
+	}
+	e.windows[key] = amounts
+	sum := uint64(0)
+	for _, amount := range amounts {
+		sum += amount.Amount
+	}
+	return sum
+}
+
+func allows(allowed []string, values ...string) bool {
+	for _, allowedValue := range allowed {
+		if allowedValue == Any {
+			return true
+		}
+		for _, value := range values {
+			if allowedValue == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func uniqueKeys(keys []ids.ShortID) []ids.ShortID {
+	unique := make([]ids.ShortID, 0, len(keys))
+	seen := make(map[ids.ShortID]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			unique = append(unique, key)
+		}
+	}
+	return unique
+}
+
+func (e *Engine) loadWindows() error {
+	if e.statePath == "" {
+		return nil
+	}
+	stateBytes, err := os.ReadFile(e.statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(stateBytes, &e.windows); err != nil {
+		return fmt.Errorf("%s: %w", e.statePath, err)
+	}
+	return nil
+}
+
+// saveWindows writes committed amounts of windows to state file, if policy has it
+func (e *Engine) saveWindows() error {
+	if e.statePath == "" {
+		return nil
+	}
+	committed := make(map[string][]*signedAmount, len(e.windows))
+	for key, amounts := range e.windows {
+		for _, amount := range amounts {
+			if !amount.reserved {
+				committed[key] = append(committed[key], amount)
+			}
+		}
+	}
+	stateBytes, err := json.MarshalIndent(committed, "", "  ")
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(e.statePath, stateBytes)
+}
@@ -0,0 +1,309 @@
+package policy
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	testKey       = ids.ShortID{1}
+	testOtherKey  = ids.ShortID{2}
+	testDestAddr  = ids.ShortID{3}
+	testEVMDest   = common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	testStartTime = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// authorizeStep is request authorized at time offset from testStartTime and rules, that are expected to deny it
+type authorizeStep struct {
+	at     time.Duration
+	req    *Request
+	denied []string
+}
+
+func TestEngineAuthorize(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  *Rule
+		steps []authorizeStep
+	}{
+		{
+			name: "tx types",
+			rule: &Rule{TxTypes: []string{"BaseTx", "C.ExportTx"}},
+			steps: []authorizeStep{
+				{req: &Request{Chain: "P", TxType: "BaseTx"}},
+				{req: &Request{Chain: "C", TxType: "ExportTx"}},
+				{req: &Request{Chain: "P", TxType: "ExportTx"}, denied: []string{RuleTxType}},
+			},
+		},
+		{
+			name: "max amount",
+			rule: &Rule{TxTypes: []string{Any}, MaxAmount: 100},
+			steps: []authorizeStep{
+				{req: &Request{TxType: "BaseTx", Amount: 100}},
+				{req: &Request{TxType: "BaseTx", Amount: 101}, denied: []string{RuleMaxAmount}},
+			},
+		},
+		{
+			name: "window amounts are pruned after window",
+			rule: &Rule{TxTypes: []string{Any}, MaxWindowAmount: 100, Window: Duration(time.Hour)},
+			steps: []authorizeStep{
+				{at: 0, req: &Request{TxType: "BaseTx", Amount: 60}},
+				{at: 30 * time.Minute, req: &Request{TxType: "BaseTx", Amount: 40}},
+				{at: 59 * time.Minute, req: &Request{TxType: "BaseTx", Amount: 1}, denied: []string{RuleWindow}},
+				// first amount is out of window, only 40 is left
+				{at: 61 * time.Minute, req: &Request{TxType: "BaseTx", Amount: 60}},
+				{at: 62 * time.Minute, req: &Request{TxType: "BaseTx", Amount: 1}, denied: []string{RuleWindow}},
+				{at: 3 * time.Hour, req: &Request{TxType: "BaseTx", Amount: 100}},
+			},
+		},
+		{
+			name: "denied amount isn't added to window",
+			rule: &Rule{TxTypes: []string{Any}, MaxAmount: 50, MaxWindowAmount: 100, Window: Duration(time.Hour)},
+			steps: []authorizeStep{
+				{req: &Request{TxType: "BaseTx", Amount: 90}, denied: []string{RuleMaxAmount}},
+				{req: &Request{TxType: "BaseTx", Amount: 50}},
+				{req: &Request{TxType: "BaseTx", Amount: 50}},
+				{req: &Request{TxType: "BaseTx", Amount: 1}, denied: []string{RuleWindow}},
+			},
+		},
+		{
+			name: "window amount overflow",
+			rule: &Rule{TxTypes: []string{Any}, MaxWindowAmount: math.MaxUint64, Window: Duration(time.Hour)},
+			steps: []authorizeStep{
+				{req: &Request{TxType: "BaseTx", Amount: math.MaxUint64 - 1}},
+				{req: &Request{TxType: "BaseTx", Amount: 2}, denied: []string{RuleWindow}},
+				{req: &Request{TxType: "BaseTx", Amount: 1}},
+			},
+		},
+		{
+			name: "destinations",
+			rule: &Rule{TxTypes: []string{Any}, Destinations: []string{testDestAddr.String(), testEVMDest.Hex()}},
+			steps: []authorizeStep{
+				{req: &Request{TxType: "BaseTx", Destinations: []Destination{AddressDestination(testDestAddr)}}},
+				{req: &Request{TxType: "ImportTx", Destinations: []Destination{EVMDestination(testEVMDest)}}},
+				{req: &Request{TxType: "BaseTx", Destinations: []Destination{
+					AddressDestination(testDestAddr),
+					AddressDestination(testOtherKey),
+				}}, denied: []string{RuleDestination}},
+				{req: &Request{TxType: "ImportTx", Destinations: []Destination{EVMDestination(common.Address{})}}, denied: []string{RuleDestination}},
+			},
+		},
+		{
+			name: "proposals and votes",
+			rule: &Rule{TxTypes: []string{Any}, Proposals: []string{"BaseFee"}, Votes: []string{Any}},
+			steps: []authorizeStep{
+				{req: &Request{TxType: "AddProposalTx", ProposalKind: "BaseFee"}},
+				{req: &Request{TxType: "AddProposalTx", ProposalKind: "AddMember"}, denied: []string{RuleProposal}},
+				{req: &Request{TxType: "AddVoteTx", ProposalKind: "AddMember"}},
+			},
+		},
+		{
+			name: "votes",
+			rule: &Rule{TxTypes: []string{Any}, Proposals: []string{Any}, Votes: []string{"BaseFee"}},
+			steps: []authorizeStep{
+				{req: &Request{TxType: "AddVoteTx", ProposalKind: "BaseFee"}},
+				{req: &Request{TxType: "AddVoteTx", ProposalKind: "AddMember"}, denied: []string{RuleVote}},
+			},
+		},
+		{
+			name: "several reasons",
+			rule: &Rule{TxTypes: []string{"BaseTx"}, MaxAmount: 10, Destinations: []string{testDestAddr.String()}},
+			steps: []authorizeStep{
+				{req: &Request{TxType: "ExportTx", Amount: 11, Destinations: []Destination{AddressDestination(testOtherKey)}},
+					denied: []string{RuleTxType, RuleMaxAmount, RuleDestination}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := NewEngine(&Policy{Keys: map[string]*Rule{testKey.String(): tt.rule}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, step := range tt.steps {
+				now := testStartTime.Add(step.at)
+				engine.now = func() time.Time { return now }
+				step.req.Keys = []ids.ShortID{testKey}
+				if denied := deniedRules(t, authorize(engine, step.req)); !reflect.DeepEqual(denied, step.denied) {
+					t.Errorf("step %d: denied by %v, expected %v", i, denied, step.denied)
+				}
+			}
+		})
+	}
+}
+
+func TestEngineAuthorizeKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *Policy
+		req    *Request
+		denied []string
+	}{
+		{
+			name:   "key without rule",
+			policy: &Policy{Keys: map[string]*Rule{testKey.String(): {TxTypes: []string{Any}}}},
+			req:    &Request{TxType: "BaseTx", Keys: []ids.ShortID{testKey, testOtherKey}},
+			denied: []string{RuleKey},
+		},
+		{
+			name: "default rule",
+			policy: &Policy{
+				Keys:    map[string]*Rule{testKey.String(): {TxTypes: []string{Any}}},
+				Default: &Rule{TxTypes: []string{"BaseTx"}},
+			},
+			req: &Request{TxType: "BaseTx", Keys: []ids.ShortID{testKey, testOtherKey}},
+		},
+		{
+			name: "every key must allow",
+			policy: &Policy{
+				Keys:    map[string]*Rule{testKey.String(): {TxTypes: []string{Any}}},
+				Default: &Rule{TxTypes: []string{"BaseTx"}},
+			},
+			req:    &Request{TxType: "ExportTx", Keys: []ids.ShortID{testKey, testOtherKey}},
+			denied: []string{RuleTxType},
+		},
+		{
+			name: "network",
+			policy: &Policy{
+				Networks: []uint32{1000},
+				Default:  &Rule{TxTypes: []string{Any}},
+			},
+			req:    &Request{NetworkID: 1, TxType: "BaseTx", Keys: []ids.ShortID{testKey}},
+			denied: []string{RuleNetwork},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := NewEngine(tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if denied := deniedRules(t, authorize(engine, tt.req)); !reflect.DeepEqual(denied, tt.denied) {
+				t.Errorf("denied by %v, expected %v", denied, tt.denied)
+			}
+		})
+	}
+}
+
+func TestEngineWindowsState(t *testing.T) {
+	policy := &Policy{
+		Keys: map[string]*Rule{
+			testKey.String(): {TxTypes: []string{Any}, MaxWindowAmount: 100, Window: Duration(time.Hour)},
+		},
+		StatePath: filepath.Join(t.TempDir(), "policy.state.json"),
+	}
+	engine, err := NewEngine(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.now = func() time.Time { return testStartTime }
+	if err := authorize(engine, &Request{TxType: "BaseTx", Amount: 70, Keys: []ids.ShortID{testKey}}); err != nil {
+		t.Fatal(err)
+	}
+	// reserved amount isn't saved
+	if _, err := engine.Authorize(&Request{TxType: "BaseTx", Amount: 30, Keys: []ids.ShortID{testKey}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// window is loaded by next engine
+	engine, err = NewEngine(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.now = func() time.Time { return testStartTime.Add(time.Minute) }
+	err = authorize(engine, &Request{TxType: "BaseTx", Amount: 40, Keys: []ids.ShortID{testKey}})
+	if denied := deniedRules(t, err); !reflect.DeepEqual(denied, []string{RuleWindow}) {
+		t.Errorf("denied by %v, expected window", denied)
+	}
+	if err := authorize(engine, &Request{TxType: "BaseTx", Amount: 30, Keys: []ids.ShortID{testKey}}); err != nil {
+		t.Errorf("expected uncommitted amount to be dropped, got %v", err)
+	}
+}
+
+func TestEngineReservation(t *testing.T) {
+	engine, err := NewEngine(&Policy{
+		Keys: map[string]*Rule{
+			testKey.String(): {TxTypes: []string{Any}, MaxWindowAmount: 100, Window: Duration(time.Hour)},
+		},
+		Default:   &Rule{TxTypes: []string{Any}},
+		StatePath: filepath.Join(t.TempDir(), "policy.state.json"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.now = func() time.Time { return testStartTime }
+	req := &Request{TxType: "BaseTx", Amount: 60, Keys: []ids.ShortID{testKey}}
+
+	// held reservation counts in window
+	declined, err := engine.Authorize(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if denied := deniedRules(t, authorize(engine, req)); !reflect.DeepEqual(denied, []string{RuleWindow}) {
+		t.Errorf("denied by %v, expected window", denied)
+	}
+
+	// released reservation of declined or failed tx doesn't
+	declined.Release()
+	failed, err := engine.Authorize(req)
+	if err != nil {
+		t.Fatalf("expected released amount to be dropped, got %v", err)
+	}
+	failed.Release()
+	failed.Release()
+	if err := authorize(engine, req); err != nil {
+		t.Fatalf("expected released amount to be dropped, got %v", err)
+	}
+	if denied := deniedRules(t, authorize(engine, req)); !reflect.DeepEqual(denied, []string{RuleWindow}) {
+		t.Errorf("denied by %v, expected window", denied)
+	}
+
+	// recorded amount of tx signed elsewhere counts, keys without windows are skipped
+	engine.now = func() time.Time { return testStartTime.Add(2 * time.Hour) }
+	if err := engine.Record(&Request{TxType: "BaseTx", Amount: 90, Keys: []ids.ShortID{testKey, testOtherKey}}); err != nil {
+		t.Fatal(err)
+	}
+	if denied := deniedRules(t, authorize(engine, &Request{TxType: "BaseTx", Amount: 20, Keys: []ids.ShortID{testKey}})); !reflect.DeepEqual(denied, []string{RuleWindow}) {
+		t.Errorf("denied by %v, expected window", denied)
+	}
+}
+
+func TestNewEngineWindowWithoutDuration(t *testing.T) {
+	_, err := NewEngine(&Policy{Default: &Rule{TxTypes: []string{Any}, MaxWindowAmount: 1}})
+	if !errors.Is(err, errNoWindow) {
+		t.Errorf("expected errNoWindow, got %v", err)
+	}
+}
+
+// authorize authorizes request and commits its reservation
+func authorize(engine *Engine, req *Request) error {
+	reservation, err := engine.Authorize(req)
+	if err != nil {
+		return err
+	}
+	return reservation.Commit()
+}
+
+// deniedRules returns rules of denial reasons, nil if err is nil
+func deniedRules(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	denial := &Denial{}
+	if !errors.As(err, &denial) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rules := make([]string, len(denial.Reasons))
+	for i, reason := range denial.Reasons {
+		rules[i] = reason.Rule
+	}
+	return rules
+}
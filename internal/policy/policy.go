@@ -0,0 +1,125 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Any in TxTypes, Proposals or Votes allows any tx type or proposal kind
+const Any = "*"
+
+var (
+	errInvalidAddress = errors.New("invalid address")
+	errNoWindow       = errors.New("maxWindowAmount requires window")
+)
+
+// Policy is signing policy file: rules, that are checked before keys sign txs
+type Policy struct {
+	// Networks are ids of networks, which txs can be signed, any if empty
+	Networks []uint32 `json:"networks,omitempty"`
+	// Keys are rules of keys by key address: bech32 with any chain prefix or short id
+	Keys map[string]*Rule `json:"keys"`
+	// Default is rule of keys, that aren't in Keys. Without it such keys can't sign.
+	Default *Rule `json:"default,omitempty"`
+	// StatePath is file, where amounts signed in rolling windows are kept between runs.
+	// If empty, windows are kept only in memory.
+	StatePath string `json:"statePath,omitempty"`
+}
+
+// Rule limits txs, that key can sign. Every key, that signs tx, must allow it.
+type Rule struct {
+	// TxTypes are allowed tx types: type (e.g. BaseTx) of any chain or chain and type (e.g. C.ExportTx).
+	// C-Chain eth txs are C.EthTx, C.ContractDeploy, C.ERC20Transfer and C.ERC20Approve.
+	TxTypes []string `json:"txTypes"`
+	// MaxAmount is max nCAM, that tx can send to addresses of others, unlimited if 0
+	MaxAmount uint64 `json:"maxAmount,omitempty"`
+	// MaxWindowAmount is max nCAM, that txs signed during rolling Window can send to others
+	MaxWindowAmount uint64   `json:"maxWindowAmount,omitempty"`
+	Window          Duration `json:"window,omitempty"`
+	// Destinations are addresses, that tx can send funds to, any if empty.
+	// Bech32 with any chain prefix, short id or 0x evm address.
+	// Erc20 transfer or approve must be allowed to both token and its recipient or spender.
+	Destinations []string `json:"destinations,omitempty"`
+	// Proposals are kinds of proposals (e.g. BaseFee), that key can submit
+	Proposals []string `json:"proposals,omitempty"`
+	// Votes are kinds of proposals, that key can vote on
+	Votes []string `json:"votes,omitempty"`
+}
+
+// Duration is time.Duration, that is written as string in json, e.g. "24h"
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var durationStr string
+	if err := json.Unmarshal(b, &durationStr); err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// ReadPolicy reads policy json file
+func ReadPolicy(path string) (*Policy, error) {
+	policyBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	policy := &Policy{}
+	decoder := json.NewDecoder(bytes.NewReader(policyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// Destination is address, that tx sends funds to
+type Destination string
+
+// AddressDestination returns destination of avax address
+func AddressDestination(addr ids.ShortID) Destination {
+	return Destination(addr.String())
+}
+
+// EVMDestination returns destination of evm address
+func EVMDestination(addr common.Address) Destination {
+	return Destination(strings.ToLower(addr.Hex()))
+}
+
+func parseDestination(addrStr string) (Destination, error) {
+	if common.IsHexAddress(addrStr) {
+		return EVMDestination(common.HexToAddress(addrStr)), nil
+	}
+	addr, err := parseKeyAddress(addrStr)
+	if err != nil {
+		return "", err
+	}
+	return AddressDestination(addr), nil
+}
+
+func parseKeyAddress(addrStr string) (ids.ShortID, error) {
+	if _, _, addrBytes, err := address.Parse(addrStr); err == nil {
+		return ids.ToShortID(addrBytes)
+	}
+	if addr, err := ids.ShortFromString(addrStr); err == nil {
+		return addr, nil
+	}
+	return ids.ShortEmpty, fmt.Errorf("%w: %q", errInvalidAddress, addrStr)
+}
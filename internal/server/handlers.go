@@ -0,0 +1,500 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/policy"
+	"caminoclient/internal/signer"
+	"caminoclient/pkg/camino"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	errMethodNotAllowed = errors.New("method not allowed")
+	errUnknownChain     = errors.New("unknown chain, expected P or C")
+	errNoAuthority      = errors.New("authority address is required with several keys")
+	errPartialIssue     = errors.New("can't issue partially signed tx")
+	errInvalidEVMAddr   = errors.New("invalid evm address")
+)
+
+// badRequestError is error caused by invalid request, not by its processing
+type badRequestError struct{ error }
+
+func badRequest(err error) error {
+	return &badRequestError{err}
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/v1/keys", handleGet(s, s.keys))
+	mux.Handle("/v1/p/transfer", handlePost(s, s.pTransfer))
+	mux.Handle("/v1/p/address-state", handlePost(s, s.pAddressState))
+	mux.Handle("/v1/p/msig-alias", handlePost(s, s.pMsigAlias))
+	mux.Handle("/v1/p/vote", handlePost(s, s.pVote))
+	mux.Handle("/v1/p/export", handlePost(s, s.pExport))
+	mux.Handle("/v1/p/import", handlePost(s, s.pImport))
+	mux.Handle("/v1/c/export", handlePost(s, s.cExport))
+	mux.Handle("/v1/c/import", handlePost(s, s.cImport))
+	mux.Handle("/v1/partial/sign", handlePost(s, s.partialSign))
+	mux.Handle("/v1/tx/decode", handlePost(s, s.txDecode))
+	mux.Handle("/v1/tx/issue", handlePost(s, s.txIssue))
+	mux.Handle("/v1/msg/verify", handlePost(s, s.msgVerify))
+	return mux
+}
+
+// handlePost decodes json request body and writes json result of call
+func handlePost[Req any](s *Server, call func(context.Context, *Req) (interface{}, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			s.writeError(w, r, http.StatusMethodNotAllowed, errMethodNotAllowed)
+			return
+		}
+		req := new(Req)
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(req); err != nil {
+			s.writeError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		writeResult(s, w, r, call, req)
+	})
+}
+
+func handleGet(s *Server, call func(context.Context, *struct{}) (interface{}, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			s.writeError(w, r, http.StatusMethodNotAllowed, errMethodNotAllowed)
+			return
+		}
+		writeResult(s, w, r, call, &struct{}{})
+	})
+}
+
+func writeResult[Req any](s *Server, w http.ResponseWriter, r *http.Request, call func(context.Context, *Req) (interface{}, error), req *Req) {
+	result, err := call(r.Context(), req)
+	var (
+		badRequestErr *badRequestError
+		denial        *policy.Denial
+	)
+	switch {
+	case errors.As(err, &badRequestErr):
+		s.writeError(w, r, http.StatusBadRequest, err)
+	case errors.As(err, &denial):
+		s.logger.Errorf("[%s] %v", requestID(r), err)
+		s.writeJSON(w, r, http.StatusForbidden, &errorResponse{
+			Error:     err.Error(),
+			RequestID: requestID(r),
+			Reasons:   denial.Reasons,
+		})
+	case err != nil:
+		status, ok := kindStatuses[errkind.Of(err)]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		s.writeError(w, r, status, err)
+	default:
+		s.writeJSON(w, r, http.StatusOK, result)
+	}
+}
+
+// kindStatuses are http statuses of failed calls by error kind
+var kindStatuses = map[errkind.Kind]int{
+	errkind.InvalidInput:      http.StatusBadRequest,
+	errkind.InsufficientFunds: http.StatusUnprocessableEntity,
+	errkind.WrongNetwork:      http.StatusUnprocessableEntity,
+	errkind.SignatureMissing:  http.StatusUnprocessableEntity,
+	errkind.TxRejected:        http.StatusUnprocessableEntity,
+	errkind.RPCUnavailable:    http.StatusBadGateway,
+}
+
+// authorityRequest is executor, proposer or voter: address (can be multisig alias),
+// keystore names of its signer keys and alias owners, that sign tx
+type authorityRequest struct {
+	Address string   `json:"address,omitempty"`
+	Keys    []string `json:"keys"`
+	Signers []string `json:"signers,omitempty"`
+}
+
+func (s *Server) authority(req *authorityRequest) (*camino.Authority, error) {
+	keys, err := s.keystore.Keys(req.Keys)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	if req.Address == "" {
+		if len(keys) != 1 || len(req.Signers) > 0 {
+			return nil, badRequest(errNoAuthority)
+		}
+		return camino.KeyAuthority(keys[0]), nil
+	}
+	auth := &camino.Authority{Keys: keys, Signers: make([]ids.ShortID, len(req.Signers))}
+	if auth.Address, err = camino.ParseAddress(req.Address); err != nil {
+		return nil, badRequest(err)
+	}
+	for i, signer := range req.Signers {
+		if auth.Signers[i], err = camino.ParseAddress(signer); err != nil {
+			return nil, badRequest(err)
+		}
+	}
+	return auth, nil
+}
+
+// txResponse is built tx, issued if requested, or partially signed tx, if it misses signatures
+type txResponse struct {
+	*camino.TxResult
+	Issued  bool               `json:"issued"`
+	Partial *camino.PartialPTx `json:"partial,omitempty"`
+}
+
+func (s *Server) pTxResponse(ctx context.Context, result *camino.PTxResult, issue bool) (*txResponse, error) {
+	response := &txResponse{TxResult: &result.TxResult}
+	if len(result.MissingSigners) > 0 {
+		if issue {
+			return nil, badRequest(errPartialIssue)
+		}
+		partial, err := s.client.NewPartialPTx(result)
+		if err != nil {
+			return nil, err
+		}
+		response.Partial = partial
+		return response, nil
+	}
+	if issue {
+		if err := s.client.IssuePTx(ctx, result.Tx.Bytes()); err != nil {
+			return nil, err
+		}
+		response.Issued = true
+	}
+	return response, nil
+}
+
+func (s *Server) cTxResponse(ctx context.Context, result *camino.CTxResult, issue bool) (*txResponse, error) {
+	response := &txResponse{TxResult: &result.TxResult}
+	if issue {
+		if err := s.client.IssueCTx(ctx, result.Tx.Bytes()); err != nil {
+			return nil, err
+		}
+		response.Issued = true
+	}
+	return response, nil
+}
+
+type keyInfo struct {
+	Name       string         `json:"name"`
+	Address    string         `json:"address"`
+	EVMAddress common.Address `json:"evmAddress"`
+}
+
+func (s *Server) keys(ctx context.Context, _ *struct{}) (interface{}, error) {
+	names := s.keystore.Names()
+	keys := make([]*keyInfo, len(names))
+	for i, name := range names {
+		key, err := s.keystore.Key(name)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := address.Format("P", s.client.HRP(), key.Address().Bytes())
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = &keyInfo{Name: name, Address: addr, EVMAddress: camino.EVMAddress(key)}
+	}
+	return keys, nil
+}
+
+type pTransferRequest struct {
+	Recipients []*camino.TransferRecipient `json:"recipients"`
+	Memo       string                      `json:"memo,omitempty"`
+	FundsKey   string                      `json:"fundsKey"`
+	Issue      bool                        `json:"issue"`
+}
+
+func (s *Server) pTransfer(ctx context.Context, req *pTransferRequest) (interface{}, error) {
+	fundsKey, err := s.keystore.Key(req.FundsKey)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	result, err := s.client.TransferTx(ctx, req.Recipients, []byte(req.Memo), fundsKey)
+	if err != nil {
+		return nil, err
+	}
+	return s.pTxResponse(ctx, result, req.Issue)
+}
+
+type pAddressStateRequest struct {
+	Address  string           `json:"address"`
+	State    uint8            `json:"state"`
+	Remove   bool             `json:"remove"`
+	FundsKey string           `json:"fundsKey"`
+	Executor authorityRequest `json:"executor"`
+	Issue    bool             `json:"issue"`
+}
+
+func (s *Server) pAddressState(ctx context.Context, req *pAddressStateRequest) (interface{}, error) {
+	addr, err := camino.ParseAddress(req.Address)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	fundsKey, err := s.keystore.Key(req.FundsKey)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	executor, err := s.authority(&req.Executor)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.client.AddressStateTx(ctx, addr, as.AddressStateBit(req.State), req.Remove, fundsKey, executor)
+	if err != nil {
+		return nil, err
+	}
+	return s.pTxResponse(ctx, result, req.Issue)
+}
+
+type pMsigAliasRequest struct {
+	Owners    []string `json:"owners"`
+	Threshold uint32   `json:"threshold"`
+	FundsKey  string   `json:"fundsKey"`
+	Issue     bool     `json:"issue"`
+}
+
+func (s *Server) pMsigAlias(ctx context.Context, req *pMsigAliasRequest) (interface{}, error) {
+	fundsKey, err := s.keystore.Key(req.FundsKey)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	result, err := s.client.MsigAliasTx(ctx, req.Owners, req.Threshold, fundsKey)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.pTxResponse(ctx, result.PTxResult, req.Issue)
+	if err != nil {
+		return nil, err
+	}
+	return &struct {
+		*txResponse
+		AliasID      ids.ShortID `json:"aliasID"`
+		AliasAddress string      `json:"aliasAddress"`
+	}{response, result.AliasID, result.AliasAddress}, nil
+}
+
+type pVoteRequest struct {
+	ProposalID ids.ID           `json:"proposalID"`
+	Option     uint32           `json:"option"`
+	FundsKey   string           `json:"fundsKey"`
+	Voter      authorityRequest `json:"voter"`
+	Issue      bool             `json:"issue"`
+}
+
+func (s *Server) pVote(ctx context.Context, req *pVoteRequest) (interface{}, error) {
+	fundsKey, err := s.keystore.Key(req.FundsKey)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	voter, err := s.authority(&req.Voter)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.client.VoteTx(ctx, req.ProposalID, req.Option, fundsKey, voter)
+	if err != nil {
+		return nil, err
+	}
+	return s.pTxResponse(ctx, result, req.Issue)
+}
+
+type pExportRequest struct {
+	Amount      uint64 `json:"amount"`
+	Recipient   string `json:"recipient"`
+	FundsKey    string `json:"fundsKey"`
+	TargetChain string `json:"targetChain"`
+	Issue       bool   `json:"issue"`
+}
+
+func (s *Server) pExport(ctx context.Context, req *pExportRequest) (interface{}, error) {
+	recipient, err := camino.ParseAddress(req.Recipient)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	fundsKey, err := s.keystore.Key(req.FundsKey)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	result, err := s.client.PExportTx(ctx, req.Amount, recipient, fundsKey, req.TargetChain)
+	if err != nil {
+		return nil, err
+	}
+	return s.pTxResponse(ctx, result, req.Issue)
+}
+
+type importRequest struct {
+	SourceChain string `json:"sourceChain"`
+	SourceTxID  ids.ID `json:"sourceTxID"`
+	Key         string `json:"key"`
+	Recipient   string `json:"recipient,omitempty"` // evm address, C-Chain import only
+	Issue       bool   `json:"issue"`
+}
+
+func (s *Server) pImport(ctx context.Context, req *importRequest) (interface{}, error) {
+	key, err := s.keystore.Key(req.Key)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	result, err := s.client.PImportTx(ctx, req.SourceChain, req.SourceTxID, key)
+	if err != nil {
+		return nil, err
+	}
+	return s.pTxResponse(ctx, result, req.Issue)
+}
+
+type cExportRequest struct {
+	Recipients  []*camino.TransferRecipient `json:"recipients"`
+	FundsKeys   []string                    `json:"fundsKeys"`
+	TargetChain string                      `json:"targetChain"`
+	Issue       bool                        `json:"issue"`
+}
+
+func (s *Server) cExport(ctx context.Context, req *cExportRequest) (interface{}, error) {
+	fundsKeys, err := s.keystore.Keys(req.FundsKeys)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	result, err := s.client.EVMExportTx(ctx, req.Recipients, fundsKeys, req.TargetChain)
+	if err != nil {
+		return nil, err
+	}
+	return s.cTxResponse(ctx, result, req.Issue)
+}
+
+func (s *Server) cImport(ctx context.Context, req *importRequest) (interface{}, error) {
+	key, err := s.keystore.Key(req.Key)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	recipient := camino.EVMAddress(key)
+	if req.Recipient != "" {
+		if !common.IsHexAddress(req.Recipient) {
+			return nil, badRequest(fmt.Errorf("%w: %q", errInvalidEVMAddr, req.Recipient))
+		}
+		recipient = common.HexToAddress(req.Recipient)
+	}
+	result, err := s.client.CImportTx(ctx, req.SourceChain, req.SourceTxID, key, recipient)
+	if err != nil {
+		return nil, err
+	}
+	return s.cTxResponse(ctx, result, req.Issue)
+}
+
+type partialSignRequest struct {
+	Partial *camino.PartialPTx `json:"partial"`
+	Keys    []string           `json:"keys"`
+}
+
+func (s *Server) partialSign(ctx context.Context, req *partialSignRequest) (interface{}, error) {
+	if req.Partial == nil {
+		return nil, badRequest(errors.New("partial tx is required"))
+	}
+	keys, err := s.keystore.Keys(req.Keys)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	if err := s.client.SignPartialPTx(ctx, req.Partial, keys); err != nil {
+		return nil, err
+	}
+	return req.Partial, nil
+}
+
+type txRequest struct {
+	Chain string `json:"chain"`
+	Tx    string `json:"tx"` // hex
+}
+
+func (r *txRequest) bytes() ([]byte, error) {
+	if r.Chain = strings.ToUpper(r.Chain); r.Chain != "P" && r.Chain != "C" {
+		return nil, badRequest(fmt.Errorf("%w: %q", errUnknownChain, r.Chain))
+	}
+	txBytes, err := formatting.Decode(formatting.Hex, r.Tx)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	return txBytes, nil
+}
+
+func (s *Server) txDecode(ctx context.Context, req *txRequest) (interface{}, error) {
+	txBytes, err := req.bytes()
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := camino.DecodeTx(req.Chain, txBytes)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	return decoded, nil
+}
+
+func (s *Server) txIssue(ctx context.Context, req *txRequest) (interface{}, error) {
+	txBytes, err := req.bytes()
+	if err != nil {
+		return nil, err
+	}
+	if req.Chain == "P" {
+		err = s.client.IssuePTx(ctx, txBytes)
+	} else {
+		err = s.client.IssueCTx(ctx, txBytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return map[string]ids.ID{"txID": ids.ID(hashing.ComputeHash256Array(txBytes))}, nil
+}
+
+type msgVerifyRequest struct {
+	Message         string          `json:"message"`
+	Hex             bool            `json:"hex"` // message is 0x hex encoded bytes
+	Signature       string          `json:"signature"`
+	Mode            signer.HashMode `json:"mode"`
+	ExpectedAddress string          `json:"expectedAddress,omitempty"`
+}
+
+func (s *Server) msgVerify(ctx context.Context, req *msgVerifyRequest) (interface{}, error) {
+	msg := []byte(req.Message)
+	if req.Hex {
+		var err error
+		if msg, err = hexutil.Decode(req.Message); err != nil {
+			return nil, badRequest(err)
+		}
+	}
+	if req.Mode == "" {
+		req.Mode = signer.HashModeRaw
+	}
+	sig, err := signer.DecodeSignature(req.Signature)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	verifier := signer.NewMsgVerifier(s.logger)
+	if req.ExpectedAddress == "" {
+		recoveredSigner, err := verifier.Recover(msg, sig, req.Mode, s.client.NetworkID())
+		if err != nil {
+			return nil, badRequest(err)
+		}
+		return recoveredSigner, nil
+	}
+	recoveredSigner, ok, err := verifier.Verify(msg, sig, req.Mode, req.ExpectedAddress, s.client.NetworkID())
+	if err != nil {
+		return nil, badRequest(err)
+	}
+	return &struct {
+		*signer.RecoveredSigner
+		Valid bool `json:"valid"`
+	}{recoveredSigner, ok}, nil
+}
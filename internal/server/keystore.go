@@ -0,0 +1,54 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"caminoclient/pkg/camino"
+)
+
+var errUnknownKey = errors.New("unknown keystore key")
+
+// Keystore holds keys, that api requests reference by name, so private keys
+// never travel over http
+type Keystore struct {
+	keys map[string]*camino.PrivateKey
+}
+
+// NewKeystore creates keystore with named keys
+func NewKeystore(keys map[string]*camino.PrivateKey) *Keystore {
+	return &Keystore{keys: keys}
+}
+
+// Key returns key with given name
+func (k *Keystore) Key(name string) (*camino.PrivateKey, error) {
+	key, ok := k.keys[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownKey, name)
+	}
+	return key, nil
+}
+
+// Keys returns signers of keys with given names
+func (k *Keystore) Keys(names []string) ([]camino.Signer, error) {
+	keys := make([]camino.Signer, len(names))
+	for i, name := range names {
+		key, err := k.Key(name)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// Names returns sorted key names
+func (k *Keystore) Names() []string {
+	names := make([]string, 0, len(k.keys))
+	for name := range k.keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
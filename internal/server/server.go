@@ -0,0 +1,224 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/logger"
+	"caminoclient/internal/policy"
+	"caminoclient/pkg/camino"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	maxRequestIDLen = 64
+	maxBodySize     = 1 << 20
+
+	readHeaderTimeout = 10 * time.Second
+)
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+var errNoAPITokens = errors.New("at least one api token is required")
+
+// Config of http api server
+type Config struct {
+	// Addr is listen address, e.g. 127.0.0.1:8080
+	Addr string
+	// Tokens, one of which must be sent as "Authorization: Bearer <token>"
+	Tokens []string
+	// ShutdownTimeout is how long in-flight requests are waited for on shutdown
+	ShutdownTimeout time.Duration
+}
+
+// Server is json http api of tx builders, tx decoder, message verifier and tx issuer
+type Server struct {
+	client   *camino.Client
+	keystore *Keystore
+	logger   logger.Logger
+	cfg      Config
+	handler  http.Handler
+}
+
+// New creates api server
+func New(client *camino.Client, keystore *Keystore, logger logger.Logger, cfg Config) (*Server, error) {
+	if len(cfg.Tokens) == 0 {
+		return nil, errNoAPITokens
+	}
+	s := &Server{
+		client:   client,
+		keystore: keystore,
+		logger:   logger,
+		cfg:      cfg,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/v1/openapi.json", s.handleOpenAPI)
+	mux.Handle("/v1/", s.authorize(s.routes()))
+	s.handler = s.withRequestID(mux)
+	return s, nil
+}
+
+// Run serves api until ctx is done, then waits for in-flight requests and stops.
+// Requests don't inherit ctx, so they aren't aborted when it's done,
+// they are cancelled only if they don't finish in shutdown timeout.
+func (s *Server) Run(ctx context.Context) error {
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	httpServer := &http.Server{
+		Addr:              s.cfg.Addr,
+		Handler:           s.handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+		BaseContext:       func(net.Listener) context.Context { return baseCtx },
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		s.logger.Infof("Serving api on %s", s.cfg.Addr)
+		errs <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.logger.Info("Shutting down api server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.cfg.ShutdownTimeout)
+	defer cancel()
+	err := httpServer.Shutdown(shutdownCtx)
+	cancelRequests()
+	if err != nil {
+		s.logger.Error(err)
+		return err
+	}
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+type requestIDKey struct{}
+
+// withRequestID assigns request id (taken from X-Request-ID header or generated),
+// returns it in response header and logs request with it
+func (s *Server) withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+		r = r.WithContext(context.WithValue(r.Context(), requestIDKey{}, requestID))
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(recorder, r)
+		s.logger.Infof("[%s] %s %s %d %s", requestID, r.Method, r.URL.Path, recorder.status, time.Since(start))
+	})
+}
+
+// authorize rejects requests without valid api token
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		for _, validToken := range s.cfg.Tokens {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(validToken)) == 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		s.writeError(w, r, http.StatusUnauthorized, errors.New("invalid api token"))
+	})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	s.writeJSON(w, r, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openAPISpec)
+}
+
+// errorResponse is body of failed request
+type errorResponse struct {
+	Error string       `json:"error"`
+	Kind  errkind.Kind `json:"kind,omitempty"`
+	// Reason of tx rejection by node
+	Reason    string `json:"reason,omitempty"`
+	RequestID string `json:"requestID"`
+	// Reasons of signing denied by policy
+	Reasons []*policy.Reason `json:"reasons,omitempty"`
+}
+
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	requestID := requestID(r)
+	s.logger.Errorf("[%s] %v", requestID, err)
+	resp := &errorResponse{Error: err.Error(), Reason: errkind.ReasonOf(err), RequestID: requestID}
+	if kind := errkind.Of(err); kind != errkind.Unknown {
+		resp.Kind = kind
+	} else if status == http.StatusBadRequest {
+		resp.Kind = errkind.InvalidInput
+	}
+	s.writeJSON(w, r, status, resp)
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.logger.Errorf("[%s] %v", requestID(r), err)
+	}
+}
+
+func requestID(r *http.Request) string {
+	requestID, _ := r.Context().Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// isValidRequestID reports, if client request id is safe to be written to logs and headers:
+// it must be non-empty, not too long and only contain letters, digits, '-', '_' and '.'
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLen {
+		return false
+	}
+	for _, c := range requestID {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	idBytes := make([]byte, 8)
+	_, _ = rand.Read(idBytes)
+	return hex.EncodeToString(idBytes)
+}
+
+// statusRecorder remembers response status for request log
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,47 @@
+package signer
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// HashMode defines how message is hashed before signing
+type HashMode string
+
+const (
+	// HashModeRaw is sha256 of message, same as secp256k1.PrivateKey.Sign
+	HashModeRaw HashMode = "raw"
+	// HashModeWallet is sha256 of message prefixed the way camino wallet does it
+	HashModeWallet HashMode = "wallet"
+	// HashModeEIP191 is keccak256 of message prefixed as in eip-191 personal_sign
+	HashModeEIP191 HashMode = "eip191"
+
+	// camino wallet inherited its signed message prefix from avalanche wallet
+	walletMsgPrefix = "\x1AAvalanche Signed Message:\n"
+
+	eip191VOffset = 27
+)
+
+var errUnknownHashMode = errkind.New(errkind.InvalidInput, "unknown hash mode")
+
+// MessageHash returns 32 bytes hash that is actually signed for message in given mode
+func MessageHash(msg []byte, mode HashMode) ([]byte, error) {
+	switch mode {
+	case HashModeRaw:
+		return hashing.ComputeHash256(msg), nil
+	case HashModeWallet:
+		prefixedMsg := make([]byte, 0, len(walletMsgPrefix)+4+len(msg))
+		prefixedMsg = append(prefixedMsg, walletMsgPrefix...)
+		prefixedMsg = binary.BigEndian.AppendUint32(prefixedMsg, uint32(len(msg)))
+		prefixedMsg = append(prefixedMsg, msg...)
+		return hashing.ComputeHash256(prefixedMsg), nil
+	case HashModeEIP191:
+		return accounts.TextHash(msg), nil
+	}
+	return nil, fmt.Errorf("%w: %s", errUnknownHashMode, mode)
+}
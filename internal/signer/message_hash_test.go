@@ -0,0 +1,61 @@
+package signer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestMessageHash(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		mode HashMode
+		hash string
+	}{
+		{
+			name: "raw",
+			msg:  "hello world",
+			mode: HashModeRaw,
+			hash: "0xb94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+		},
+		// sha256("\x1AAvalanche Signed Message:\n" || uint32be(11) || "hello world"), as avalanche wallet digestMessage
+		{
+			name: "wallet",
+			msg:  "hello world",
+			mode: HashModeWallet,
+			hash: "0x312702fa0e966cec5f38a92797be2b1a40d4d31ce18f5d003260ead789bf4c53",
+		},
+		// web3.eth.accounts.hashMessage vectors
+		{
+			name: "eip-191",
+			msg:  "Hello World",
+			mode: HashModeEIP191,
+			hash: "0xa1de988600a42c4b4ab089b619297c17d53cffae5d5120d82d8a92d0bb3b78f2",
+		},
+		{
+			name: "eip-191 web3 sign",
+			msg:  "Some data",
+			mode: HashModeEIP191,
+			hash: "0x1da44b586eb0729ff70a73c326926f6ed5a25f5b056e7f47fbc6e58d86871655",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := MessageHash([]byte(tt.msg), tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hashStr := hexutil.Encode(hash); hashStr != tt.hash {
+				t.Errorf("expected hash %s, got %s", tt.hash, hashStr)
+			}
+		})
+	}
+}
+
+func TestMessageHashUnknownMode(t *testing.T) {
+	if _, err := MessageHash([]byte("hello world"), "eip712"); !errors.Is(err, errUnknownHashMode) {
+		t.Errorf("expected errUnknownHashMode, got %v", err)
+	}
+}
@@ -14,7 +14,11 @@ func NewMsgSigner(keyStr string, logger logger.Logger) (*msgSigner, error) {
 		return nil, err
 	}
 
-	return &msgSigner{key: key, logger: logger}, nil
+	return NewMsgSignerFromKey(key, logger), nil
+}
+
+func NewMsgSignerFromKey(key *secp256k1.PrivateKey, logger logger.Logger) *msgSigner {
+	return &msgSigner{key: key, logger: logger}
 }
 
 type msgSigner struct {
@@ -27,11 +31,26 @@ func (ms *msgSigner) SignStr(msg string) ([]byte, error) {
 }
 
 func (ms *msgSigner) Sign(msg []byte) ([]byte, error) {
-	sig, err := ms.key.Sign(msg)
+	return ms.SignWithHashMode(msg, HashModeRaw)
+}
+
+// SignWithHashMode signs message hashed according to mode.
+// Signature is [r || s || v], v is 27/28 for eip-191 and 0/1 otherwise.
+func (ms *msgSigner) SignWithHashMode(msg []byte, mode HashMode) ([]byte, error) {
+	hash, err := MessageHash(msg, mode)
+	if err != nil {
+		ms.logger.Error(err)
+		return nil, err
+	}
+
+	sig, err := ms.key.SignHash(hash)
 	if err != nil {
 		ms.logger.Error(err)
 		return nil, err
 	}
+	if mode == HashModeEIP191 {
+		sig[secp256k1.SignatureLen-1] += eip191VOffset
+	}
 
 	messageStr, err := formatting.Encode(formatting.Hex, msg)
 	if err != nil {
@@ -0,0 +1,64 @@
+package signer
+
+import (
+	"testing"
+
+	"caminoclient/internal/logger"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// key and address of web3.eth.accounts.sign example
+var (
+	testKeyHex     = "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	testEVMAddress = common.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+)
+
+func newTestKey(t *testing.T, keyHex string) *secp256k1.PrivateKey {
+	t.Helper()
+	key, err := (&secp256k1.Factory{}).ToPrivateKey(hexutil.MustDecode(keyHex))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestSignWithHashModeEIP191(t *testing.T) {
+	// web3.eth.accounts.sign("Some data", testKeyHex).signature
+	expected := "0xb91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c"
+	sig, err := NewMsgSignerFromKey(newTestKey(t, testKeyHex), &logger.NoLog).SignWithHashMode([]byte("Some data"), HashModeEIP191)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sigStr := hexutil.Encode(sig); sigStr != expected {
+		t.Errorf("expected signature %s, got %s", expected, sigStr)
+	}
+}
+
+func TestSignWithHashModeV(t *testing.T) {
+	msgSigner := NewMsgSignerFromKey(newTestKey(t, testKeyHex), &logger.NoLog)
+	tests := []struct {
+		mode HashMode
+		minV byte
+	}{
+		{mode: HashModeRaw, minV: 0},
+		{mode: HashModeWallet, minV: 0},
+		{mode: HashModeEIP191, minV: eip191VOffset},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			sig, err := msgSigner.SignWithHashMode([]byte("hello world"), tt.mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sig) != secp256k1.SignatureLen {
+				t.Fatalf("expected %d bytes signature, got %d", secp256k1.SignatureLen, len(sig))
+			}
+			if v := sig[secp256k1.SignatureLen-1]; v != tt.minV && v != tt.minV+1 {
+				t.Errorf("expected v %d or %d, got %d", tt.minV, tt.minV+1, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package signer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/logger"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	errInvalidSignature = errkind.New(errkind.InvalidInput, "invalid signature")
+	errInvalidAddress   = errkind.New(errkind.InvalidInput, "invalid address")
+)
+
+// RecoveredSigner is signer of message recovered from its signature
+type RecoveredSigner struct {
+	PublicKey  string         `json:"publicKey"`
+	AddressID  ids.ShortID    `json:"addressID"`
+	PAddress   string         `json:"pAddress"`
+	EVMAddress common.Address `json:"evmAddress"`
+}
+
+// Matches checks if signer has expected address,
+// which can be bech32 address with any chain prefix, short id or 0x evm address
+func (rs *RecoveredSigner) Matches(expectedAddr string) (bool, error) {
+	if common.IsHexAddress(expectedAddr) {
+		return common.HexToAddress(expectedAddr) == rs.EVMAddress, nil
+	}
+	if _, _, addrBytes, err := address.Parse(expectedAddr); err == nil {
+		return bytes.Equal(addrBytes, rs.AddressID[:]), nil
+	}
+	if addrID, err := ids.ShortFromString(expectedAddr); err == nil {
+		return addrID == rs.AddressID, nil
+	}
+	return false, fmt.Errorf("%w: %q", errInvalidAddress, expectedAddr)
+}
+
+func NewMsgVerifier(logger logger.Logger) *msgVerifier {
+	return &msgVerifier{logger: logger}
+}
+
+type msgVerifier struct {
+	logger  logger.Logger
+	factory secp256k1.Factory
+}
+
+// Recover recovers signer of message from signature made in given hash mode.
+// P-Chain address of signer is formatted for network with given id.
+func (mv *msgVerifier) Recover(msg, sig []byte, mode HashMode, networkID uint32) (*RecoveredSigner, error) {
+	hash, err := MessageHash(msg, mode)
+	if err != nil {
+		mv.logger.Error(err)
+		return nil, err
+	}
+	return mv.recoverHash(hash, sig, networkID)
+}
+
+func (mv *msgVerifier) recoverHash(hash, sig []byte, networkID uint32) (*RecoveredSigner, error) {
+	if len(sig) != secp256k1.SignatureLen {
+		err := fmt.Errorf("%w: expected %d bytes, got %d", errInvalidSignature, secp256k1.SignatureLen, len(sig))
+		mv.logger.Error(err)
+		return nil, err
+	}
+	sig = append([]byte{}, sig...)
+	if v := sig[secp256k1.SignatureLen-1]; v >= eip191VOffset {
+		sig[secp256k1.SignatureLen-1] = v - eip191VOffset
+	}
+
+	pubKey, err := mv.factory.RecoverHashPublicKey(hash, sig)
+	if err != nil {
+		mv.logger.Error(err)
+		return nil, err
+	}
+
+	pubKeyStr, err := formatting.Encode(formatting.Hex, pubKey.Bytes())
+	if err != nil {
+		mv.logger.Error(err)
+		return nil, err
+	}
+	addrID := pubKey.Address()
+	pAddr, err := address.Format("P", constants.GetHRP(networkID), addrID[:])
+	if err != nil {
+		mv.logger.Error(err)
+		return nil, err
+	}
+
+	return &RecoveredSigner{
+		PublicKey:  pubKeyStr,
+		AddressID:  addrID,
+		PAddress:   pAddr,
+		EVMAddress: crypto.PubkeyToAddress(*pubKey.ToECDSA()),
+	}, nil
+}
+
+// Verify recovers signer of message and checks it against expected address
+func (mv *msgVerifier) Verify(msg, sig []byte, mode HashMode, expectedAddr string, networkID uint32) (*RecoveredSigner, bool, error) {
+	signer, err := mv.Recover(msg, sig, mode, networkID)
+	if err != nil {
+		return nil, false, err
+	}
+	ok, err := signer.Matches(expectedAddr)
+	if err != nil {
+		mv.logger.Error(err)
+		return nil, false, err
+	}
+	return signer, ok, nil
+}
+
+// DecodeSignature decodes signature from avax hex with checksum, plain hex or cb58
+func DecodeSignature(sigStr string) ([]byte, error) {
+	if strings.HasPrefix(sigStr, "0x") {
+		if sig, err := formatting.Decode(formatting.Hex, sigStr); err == nil && len(sig) == secp256k1.SignatureLen {
+			return sig, nil
+		}
+		if sig, err := hexutil.Decode(sigStr); err == nil {
+			return sig, nil
+		}
+	} else if sig, err := formatting.Decode(formatting.CB58, sigStr); err == nil {
+		return sig, nil
+	}
+	return nil, fmt.Errorf("%w: %q", errInvalidSignature, sigStr)
+}
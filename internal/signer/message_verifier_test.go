@@ -0,0 +1,180 @@
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/logger"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSignRecover(t *testing.T) {
+	key := newTestKey(t, testKeyHex)
+	msgSigner := NewMsgSignerFromKey(key, &logger.NoLog)
+	verifier := NewMsgVerifier(&logger.NoLog)
+	msg := []byte("hello world")
+	for _, mode := range []HashMode{HashModeRaw, HashModeWallet, HashModeEIP191} {
+		t.Run(string(mode), func(t *testing.T) {
+			sig, err := msgSigner.SignWithHashMode(msg, mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// v is accepted both as 0/1 and 27/28
+			otherV := append([]byte{}, sig...)
+			if v := otherV[secp256k1.SignatureLen-1]; v >= eip191VOffset {
+				otherV[secp256k1.SignatureLen-1] = v - eip191VOffset
+			} else {
+				otherV[secp256k1.SignatureLen-1] = v + eip191VOffset
+			}
+			for _, sig := range [][]byte{sig, otherV} {
+				signer, err := verifier.Recover(msg, sig, mode, constants.KopernikusID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if signer.AddressID != key.Address() || signer.EVMAddress != testEVMAddress {
+					t.Errorf("expected signer %s %s, got %s %s", key.Address(), testEVMAddress, signer.AddressID, signer.EVMAddress)
+				}
+			}
+
+			// message signed in another mode is recovered to another signer
+			otherMode := HashModeRaw
+			if mode == HashModeRaw {
+				otherMode = HashModeWallet
+			}
+			if _, ok, err := verifier.Verify(msg, sig, otherMode, testEVMAddress.Hex(), constants.KopernikusID); err != nil || ok {
+				t.Errorf("expected mismatch of %s signature verified as %s, got %v: %v", mode, otherMode, ok, err)
+			}
+		})
+	}
+}
+
+func TestRecoverEIP191Reference(t *testing.T) {
+	// web3.eth.accounts.sign("Some data", testKeyHex).signature
+	sig := hexutil.MustDecode("0xb91467e570a6466aa9e9876cbcd013baba02900b8979d43fe208a4a4f339f5fd6007e74cd82e037b800186422fc2da167c747ef045e5d18a5f5d4300f8e1a0291c")
+	signer, ok, err := NewMsgVerifier(&logger.NoLog).Verify([]byte("Some data"), sig, HashModeEIP191, testEVMAddress.Hex(), constants.KopernikusID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("expected signer %s, got %s", testEVMAddress, signer.EVMAddress)
+	}
+}
+
+func TestRecoverInvalidSignature(t *testing.T) {
+	verifier := NewMsgVerifier(&logger.NoLog)
+	for _, sig := range [][]byte{nil, make([]byte, secp256k1.SignatureLen-1), make([]byte, secp256k1.SignatureLen+1)} {
+		if _, err := verifier.Recover([]byte("hello world"), sig, HashModeRaw, constants.KopernikusID); !errors.Is(err, errInvalidSignature) {
+			t.Errorf("expected errInvalidSignature for %d bytes signature, got %v", len(sig), err)
+		}
+	}
+}
+
+func TestDecodeSignature(t *testing.T) {
+	sig, err := NewMsgSignerFromKey(newTestKey(t, testKeyHex), &logger.NoLog).Sign([]byte("hello world"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	avaxHex, err := formatting.Encode(formatting.Hex, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb58, err := formatting.Encode(formatting.CB58, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		sigStr string
+		err    bool
+	}{
+		{name: "avax hex", sigStr: avaxHex},
+		{name: "plain hex", sigStr: hexutil.Encode(sig)},
+		{name: "cb58", sigStr: cb58},
+		{name: "invalid hex", sigStr: "0xzz", err: true},
+		{name: "invalid cb58", sigStr: "0OIl", err: true},
+		{name: "empty", sigStr: "", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := DecodeSignature(tt.sigStr)
+			if tt.err {
+				if errkind.Of(err) != errkind.InvalidInput {
+					t.Errorf("expected invalid input error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(decoded, sig) {
+				t.Errorf("expected signature %x, got %x", sig, decoded)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	key := newTestKey(t, testKeyHex)
+	msg := []byte("hello world")
+	sig, err := NewMsgSignerFromKey(key, &logger.NoLog).Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewMsgVerifier(&logger.NoLog).Recover(msg, sig, HashModeRaw, constants.KopernikusID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrID := key.Address()
+	otherKey := newTestKey(t, "0x1111111111111111111111111111111111111111111111111111111111111111")
+	otherAddrID := otherKey.Address()
+	bech32 := func(chain string, addr []byte) string {
+		addrStr, err := address.Format(chain, constants.GetHRP(constants.KopernikusID), addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return addrStr
+	}
+
+	tests := []struct {
+		name    string
+		addr    string
+		matches bool
+		err     bool
+	}{
+		{name: "P-Chain bech32", addr: bech32("P", addrID[:]), matches: true},
+		{name: "X-Chain bech32", addr: bech32("X", addrID[:]), matches: true},
+		{name: "short id", addr: addrID.String(), matches: true},
+		{name: "evm address", addr: testEVMAddress.Hex(), matches: true},
+		{name: "lowercase evm address", addr: hexutil.Encode(testEVMAddress[:]), matches: true},
+		{name: "other bech32", addr: bech32("P", otherAddrID[:])},
+		{name: "other short id", addr: otherAddrID.String()},
+		{name: "other evm address", addr: crypto.PubkeyToAddress(*otherKey.PublicKey().ToECDSA()).Hex()},
+		{name: "invalid", addr: "not an address", err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := signer.Matches(tt.addr)
+			if tt.err {
+				if !errors.Is(err, errInvalidAddress) {
+					t.Errorf("expected errInvalidAddress, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if matches != tt.matches {
+				t.Errorf("expected matches %v, got %v", tt.matches, matches)
+			}
+		})
+	}
+}
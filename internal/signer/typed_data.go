@@ -0,0 +1,88 @@
+package signer
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// TypedDataSignature is eip-712 signature in formats expected by evm contracts
+type TypedDataSignature struct {
+	Hash      hexutil.Bytes `json:"hash"`
+	Signature hexutil.Bytes `json:"signature"` // [r || s || v], v is 27/28
+	R         hexutil.Bytes `json:"r"`
+	S         hexutil.Bytes `json:"s"`
+	V         uint8         `json:"v"`
+}
+
+// ReadTypedData reads eip-712 typed data (types, primaryType, domain, message) from json file
+func ReadTypedData(path string) (*apitypes.TypedData, error) {
+	typedDataBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	typedData := &apitypes.TypedData{}
+	if err := json.Unmarshal(typedDataBytes, typedData); err != nil {
+		return nil, err
+	}
+	return typedData, nil
+}
+
+// TypedDataHash returns eip-712 hash of typed data, which is actually signed
+func TypedDataHash(typedData *apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(*typedData)
+	return hash, err
+}
+
+func (ms *msgSigner) SignTypedData(typedData *apitypes.TypedData) (*TypedDataSignature, error) {
+	hash, err := TypedDataHash(typedData)
+	if err != nil {
+		ms.logger.Error(err)
+		return nil, err
+	}
+
+	sig, err := ms.key.SignHash(hash)
+	if err != nil {
+		ms.logger.Error(err)
+		return nil, err
+	}
+	sig[secp256k1.SignatureLen-1] += eip191VOffset
+
+	ms.logger.Infof("typed data hash: %s", hexutil.Encode(hash))
+	ms.logger.Infof("signature: %s", hexutil.Encode(sig))
+
+	return &TypedDataSignature{
+		Hash:      hash,
+		Signature: sig,
+		R:         sig[:32],
+		S:         sig[32:64],
+		V:         sig[64],
+	}, nil
+}
+
+// RecoverTypedData recovers signer of eip-712 typed data from its signature
+func (mv *msgVerifier) RecoverTypedData(typedData *apitypes.TypedData, sig []byte, networkID uint32) (*RecoveredSigner, error) {
+	hash, err := TypedDataHash(typedData)
+	if err != nil {
+		mv.logger.Error(err)
+		return nil, err
+	}
+	return mv.recoverHash(hash, sig, networkID)
+}
+
+// VerifyTypedData recovers signer of eip-712 typed data and checks it against expected address
+func (mv *msgVerifier) VerifyTypedData(typedData *apitypes.TypedData, sig []byte, expectedAddr string, networkID uint32) (*RecoveredSigner, bool, error) {
+	signer, err := mv.RecoverTypedData(typedData, sig, networkID)
+	if err != nil {
+		return nil, false, err
+	}
+	ok, err := signer.Matches(expectedAddr)
+	if err != nil {
+		mv.logger.Error(err)
+		return nil, false, err
+	}
+	return signer, ok, nil
+}
@@ -0,0 +1,98 @@
+package signer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"caminoclient/internal/logger"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// eip-712 specification example
+const testMailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": "1",
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+// signature of eip-712 specification example, [r || s || v]
+const testMailSignature = "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c"
+
+func TestTypedDataMail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.json")
+	if err := os.WriteFile(path, []byte(testMailTypedData), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	typedData, err := ReadTypedData(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// signer of example is keccak256("cow")
+	key := newTestKey(t, hexutil.Encode(crypto.Keccak256([]byte("cow"))))
+	cowAddress := common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")
+
+	sig, err := NewMsgSignerFromKey(key, &logger.NoLog).SignTypedData(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &TypedDataSignature{
+		Hash: hexutil.MustDecode("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"),
+		R:    hexutil.MustDecode("0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"),
+		S:    hexutil.MustDecode("0x07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"),
+		V:    28,
+	}
+	if sig.Hash.String() != expected.Hash.String() {
+		t.Errorf("expected hash %s, got %s", expected.Hash, sig.Hash)
+	}
+	if sig.R.String() != expected.R.String() || sig.S.String() != expected.S.String() || sig.V != expected.V {
+		t.Errorf("expected r %s, s %s, v %d, got r %s, s %s, v %d", expected.R, expected.S, expected.V, sig.R, sig.S, sig.V)
+	}
+	if sigStr := sig.Signature.String(); sigStr != testMailSignature {
+		t.Errorf("expected signature %s, got %s", testMailSignature, sigStr)
+	}
+
+	signer, ok, err := NewMsgVerifier(&logger.NoLog).VerifyTypedData(typedData, sig.Signature, cowAddress.Hex(), constants.KopernikusID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || signer.AddressID != key.Address() {
+		t.Errorf("expected signer %s %s, got %s %s", key.Address(), cowAddress, signer.AddressID, signer.EVMAddress)
+	}
+
+	// changed message isn't signed by cow
+	typedData.Message["contents"] = "Hello, Alice!"
+	if _, ok, err := NewMsgVerifier(&logger.NoLog).VerifyTypedData(typedData, sig.Signature, cowAddress.Hex(), constants.KopernikusID); err != nil || ok {
+		t.Errorf("expected changed message mismatch, got %v: %v", ok, err)
+	}
+}
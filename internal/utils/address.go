@@ -0,0 +1,247 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	avax_secp256k1 "github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const privateKeyPrefix = "PrivateKey-"
+
+// AddressInputFormat is format of ConvertAddress input
+type AddressInputFormat string
+
+const (
+	// AddressInputAuto detects input format. 32 bytes hex isn't detected, it can be private key or hash,
+	// and 0x 40 hex chars are evm address, plain hex short id must be given explicitly.
+	AddressInputAuto AddressInputFormat = "auto"
+	// AddressInputPrivateKey is PrivateKey-... or 32 bytes hex private key
+	AddressInputPrivateKey AddressInputFormat = "private-key"
+	// AddressInputPublicKey is compressed or uncompressed public key, hex or cb58
+	AddressInputPublicKey AddressInputFormat = "public-key"
+	// AddressInputShortID is cb58 or hex (with or without avax checksum) short id
+	AddressInputShortID AddressInputFormat = "short-id"
+	// AddressInputBech32 is bech32 address of network, with any chain prefix or without it
+	AddressInputBech32 AddressInputFormat = "bech32"
+	// AddressInputEVM is 0x evm address
+	AddressInputEVM AddressInputFormat = "evm"
+)
+
+var (
+	errUnknownAddressFormat   = errkind.New(errkind.InvalidInput, "unknown address format")
+	errAmbiguousAddressFormat = errkind.New(errkind.InvalidInput, "ambiguous address format")
+	errInvalidAddressInput    = errkind.New(errkind.InvalidInput, "input doesn't match address format")
+	errAddressNetwork         = errkind.New(errkind.WrongNetwork, "address is of another network")
+)
+
+// AddressFormats are all representations of address for one network.
+// Fields that can't be derived from input (e.g. evm address from short id) are empty.
+type AddressFormats struct {
+	Input                 string             `json:"input"`
+	InputFormat           AddressInputFormat `json:"inputFormat"`
+	NetworkID             uint32             `json:"networkID"`
+	HRP                   string             `json:"hrp"`
+	PublicKey             string             `json:"publicKey,omitempty"`
+	PublicKeyUncompressed string             `json:"publicKeyUncompressed,omitempty"`
+	ShortID               *ids.ShortID       `json:"shortID,omitempty"`
+	ShortIDHex            string             `json:"shortIDHex,omitempty"` // avax hex with checksum
+	PAddress              string             `json:"pAddress,omitempty"`
+	XAddress              string             `json:"xAddress,omitempty"`
+	CAddress              string             `json:"cAddress,omitempty"`
+	EVMAddress            *common.Address    `json:"evmAddress,omitempty"`
+}
+
+// ConvertAddress converts input of given format into all address representations for network.
+// Input can be short id, bech32 address with any chain prefix (or without it), hex short id,
+// compressed or uncompressed public key (hex or cb58), private key or evm 0x address.
+// Bech32 address must have hrp of network. Input isn't quoted in errors, if it can be private key.
+func (u *UtilsWithLogger) ConvertAddress(input string, inputFormat AddressInputFormat, networkID uint32) (*AddressFormats, error) {
+	formats := &AddressFormats{
+		Input:     input,
+		NetworkID: networkID,
+		HRP:       constants.GetHRP(networkID),
+	}
+
+	var (
+		pubKey *avax_secp256k1.PublicKey
+		addrID ids.ShortID
+		err    error
+	)
+	if inputFormat == AddressInputAuto {
+		inputFormat, err = detectAddressFormat(input)
+		if err != nil {
+			u.logger.Error(err)
+			return nil, err
+		}
+	}
+	formats.InputFormat = inputFormat
+
+	inputBytes, isHex := decodeHexOrCB58(input)
+	switch inputFormat {
+	case AddressInputPrivateKey:
+		var key *avax_secp256k1.PrivateKey
+		switch {
+		case strings.HasPrefix(input, privateKeyPrefix):
+			key, err = ParsePrivateKey(input)
+		case isHex && len(inputBytes) == avax_secp256k1.PrivateKeyLen:
+			key, err = (&avax_secp256k1.Factory{}).ToPrivateKey(inputBytes)
+		default:
+			err = fmt.Errorf("%w: expected PrivateKey-... or 32 bytes hex", errInvalidAddressInput)
+		}
+		if err == nil {
+			pubKey = key.PublicKey()
+		}
+	case AddressInputPublicKey:
+		if len(inputBytes) != avax_secp256k1.PublicKeyLen && len(inputBytes) != 2*avax_secp256k1.PublicKeyLen-1 {
+			err = fmt.Errorf("%w: expected 33 or 65 bytes public key", errInvalidAddressInput)
+			break
+		}
+		pubKey, err = parsePublicKey(inputBytes)
+	case AddressInputShortID:
+		if len(inputBytes) != ids.ShortIDLen {
+			err = fmt.Errorf("%w: expected 20 bytes short id", errInvalidAddressInput)
+			break
+		}
+		addrID, err = ids.ToShortID(inputBytes)
+	case AddressInputBech32:
+		var hrp string
+		hrp, addrID, err = parseBech32(input)
+		if err == nil && hrp != formats.HRP {
+			err = fmt.Errorf("%w: %q has hrp %q, network %d has %q", errAddressNetwork, input, hrp, networkID, formats.HRP)
+		}
+	case AddressInputEVM:
+		if !strings.HasPrefix(input, "0x") || !common.IsHexAddress(input) {
+			err = fmt.Errorf("%w: expected 0x evm address", errInvalidAddressInput)
+			break
+		}
+		evmAddr := common.HexToAddress(input)
+		formats.EVMAddress = &evmAddr
+		return formats, nil
+	default:
+		err = fmt.Errorf("%w: %q", errUnknownAddressFormat, inputFormat)
+	}
+	if err != nil {
+		u.logger.Error(err)
+		return nil, err
+	}
+
+	if pubKey != nil {
+		addrID = pubKey.Address()
+		formats.PublicKey, err = formatting.Encode(formatting.Hex, pubKey.Bytes())
+		if err != nil {
+			u.logger.Error(err)
+			return nil, err
+		}
+		pubKeyECDSA := pubKey.ToECDSA()
+		formats.PublicKeyUncompressed = hexutil.Encode(crypto.FromECDSAPub(pubKeyECDSA))
+		evmAddr := crypto.PubkeyToAddress(*pubKeyECDSA)
+		formats.EVMAddress = &evmAddr
+	}
+
+	formats.ShortID = &addrID
+	formats.ShortIDHex, err = formatting.Encode(formatting.Hex, addrID[:])
+	if err != nil {
+		u.logger.Error(err)
+		return nil, err
+	}
+	for chain, addrStr := range map[string]*string{
+		"P": &formats.PAddress,
+		"X": &formats.XAddress,
+		"C": &formats.CAddress,
+	} {
+		*addrStr, err = address.Format(chain, formats.HRP, addrID[:])
+		if err != nil {
+			u.logger.Error(err)
+			return nil, err
+		}
+	}
+	return formats, nil
+}
+
+// detectAddressFormat returns format of input, if it's unambiguous
+func detectAddressFormat(input string) (AddressInputFormat, error) {
+	switch inputBytes, isHex := decodeHexOrCB58(input); {
+	case strings.HasPrefix(input, privateKeyPrefix):
+		return AddressInputPrivateKey, nil
+	case isHex && len(inputBytes) == avax_secp256k1.PrivateKeyLen:
+		return "", fmt.Errorf("%w: 32 bytes hex can be private key or hash, set input format %q explicitly", errAmbiguousAddressFormat, AddressInputPrivateKey)
+	case strings.HasPrefix(input, "0x") && common.IsHexAddress(input):
+		return AddressInputEVM, nil
+	case strings.Contains(input, "1") && isBech32(input):
+		return AddressInputBech32, nil
+	case len(inputBytes) == ids.ShortIDLen:
+		return AddressInputShortID, nil
+	case len(inputBytes) == avax_secp256k1.PublicKeyLen || len(inputBytes) == 2*avax_secp256k1.PublicKeyLen-1:
+		return AddressInputPublicKey, nil
+	}
+	return "", fmt.Errorf("%w: %q", errUnknownAddressFormat, input)
+}
+
+// ParseAddress parses bech32 address (with or without chain prefix) or cb58 short id
+func ParseAddress(addrStr string) (ids.ShortID, error) {
+	if _, addrID, err := parseBech32(addrStr); err == nil {
+		return addrID, nil
+	}
+	if addrID, err := ids.ShortFromString(addrStr); err == nil {
+		return addrID, nil
+	}
+	return ids.ShortEmpty, fmt.Errorf("%w: %q", errUnknownAddressFormat, addrStr)
+}
+
+// decodeHexOrCB58 decodes 0x hex (with or without avax checksum) or cb58 string,
+// returns nil if input is neither
+func decodeHexOrCB58(input string) ([]byte, bool) {
+	if strings.HasPrefix(input, "0x") {
+		if decoded, err := hexutil.Decode(input); err == nil {
+			// avax hex has 4 bytes checksum, which is verified by formatting.Decode
+			if decodedWithoutChecksum, err := formatting.Decode(formatting.Hex, input); err == nil {
+				return decodedWithoutChecksum, true
+			}
+			return decoded, true
+		}
+		return nil, false
+	}
+	if decoded, err := formatting.Decode(formatting.CB58, strings.TrimPrefix(input, privateKeyPrefix)); err == nil {
+		return decoded, false
+	}
+	return nil, false
+}
+
+func isBech32(input string) bool {
+	_, _, err := parseBech32(input)
+	return err == nil
+}
+
+// parseBech32 parses bech32 address with or without chain prefix, returns its hrp and short id
+func parseBech32(input string) (string, ids.ShortID, error) {
+	if _, hrp, addrBytes, err := address.Parse(input); err == nil {
+		addrID, err := ids.ToShortID(addrBytes)
+		return hrp, addrID, err
+	}
+	hrp, addrBytes, err := address.ParseBech32(input)
+	if err != nil {
+		return "", ids.ShortEmpty, err
+	}
+	addrID, err := ids.ToShortID(addrBytes)
+	return hrp, addrID, err
+}
+
+// parsePublicKey parses compressed or uncompressed public key
+func parsePublicKey(pubKeyBytes []byte) (*avax_secp256k1.PublicKey, error) {
+	pubKey, err := secp256k1.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+	return (&avax_secp256k1.Factory{}).ToPublicKey(pubKey.SerializeCompressed())
+}
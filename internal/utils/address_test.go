@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// well-known local network test key
+const (
+	testKey        = "PrivateKey-ewoqjP7PxY4yr3iLTpLisriqt94hdyDFNgchSxGGztUrTXtNN"
+	testHexKey     = "0x56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5ccf558d8027"
+	testShortID    = "6Y3kysjF9jnHnYkdS9yGAuoHyae2eNmeV"
+	testPAddress   = "P-local18jma8ppw3nhx5r4ap8clazz0dps7rv5u00z96u"
+	testEVMAddress = "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+)
+
+func TestConvertAddress(t *testing.T) {
+	key, err := ParsePrivateKey(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrID := key.Address()
+	pubKey, err := formatting.Encode(formatting.Hex, key.PublicKey().Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	shortIDHex, err := formatting.Encode(formatting.Hex, addrID[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	kopernikusAddress, err := address.Format("P", constants.GetHRP(constants.KopernikusID), addrID[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		input       string
+		inputFormat AddressInputFormat
+		expected    AddressInputFormat
+		hasEVM      bool
+		err         error
+	}{
+		{name: "private key", input: testKey, inputFormat: AddressInputAuto, expected: AddressInputPrivateKey, hasEVM: true},
+		{name: "hex private key", input: testHexKey, inputFormat: AddressInputPrivateKey, expected: AddressInputPrivateKey, hasEVM: true},
+		{name: "undetected hex private key", input: testHexKey, inputFormat: AddressInputAuto, err: errAmbiguousAddressFormat},
+		{name: "compressed public key", input: pubKey, inputFormat: AddressInputAuto, expected: AddressInputPublicKey, hasEVM: true},
+		{
+			name:        "uncompressed public key",
+			input:       hexutil.Encode(crypto.FromECDSAPub(key.PublicKey().ToECDSA())),
+			inputFormat: AddressInputAuto,
+			expected:    AddressInputPublicKey,
+			hasEVM:      true,
+		},
+		{name: "short id", input: testShortID, inputFormat: AddressInputAuto, expected: AddressInputShortID},
+		{name: "avax hex short id", input: shortIDHex, inputFormat: AddressInputAuto, expected: AddressInputShortID},
+		{name: "plain hex short id", input: hexutil.Encode(addrID[:]), inputFormat: AddressInputShortID, expected: AddressInputShortID},
+		{name: "bech32", input: testPAddress, inputFormat: AddressInputAuto, expected: AddressInputBech32},
+		{name: "bech32 without chain", input: strings.TrimPrefix(testPAddress, "P-"), inputFormat: AddressInputBech32, expected: AddressInputBech32},
+		{name: "bech32 of another network", input: kopernikusAddress, inputFormat: AddressInputAuto, err: errAddressNetwork},
+		{name: "evm address", input: testEVMAddress, inputFormat: AddressInputAuto, expected: AddressInputEVM, hasEVM: true},
+		{name: "short id as evm address", input: testShortID, inputFormat: AddressInputEVM, err: errInvalidAddressInput},
+		{name: "short id as private key", input: testShortID, inputFormat: AddressInputPrivateKey, err: errInvalidAddressInput},
+		{name: "private key as short id", input: testHexKey, inputFormat: AddressInputShortID, err: errInvalidAddressInput},
+		{name: "unknown input", input: "not an address", inputFormat: AddressInputAuto, err: errUnknownAddressFormat},
+		{name: "unknown input format", input: testShortID, inputFormat: "cb58", err: errUnknownAddressFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formats, err := UtilsNoLog.ConvertAddress(tt.input, tt.inputFormat, constants.LocalID)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("expected %v, got %v", tt.err, err)
+				}
+				if strings.Contains(err.Error(), strings.TrimPrefix(testHexKey, "0x")) {
+					t.Errorf("error contains private key: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if formats.InputFormat != tt.expected {
+				t.Errorf("expected input format %s, got %s", tt.expected, formats.InputFormat)
+			}
+			if tt.hasEVM != (formats.EVMAddress != nil) ||
+				tt.hasEVM && *formats.EVMAddress != common.HexToAddress(testEVMAddress) {
+				t.Errorf("expected evm address %v, got %v", tt.hasEVM, formats.EVMAddress)
+			}
+			if tt.expected == AddressInputEVM {
+				if formats.ShortID != nil {
+					t.Errorf("expected no short id for evm address, got %s", formats.ShortID)
+				}
+				return
+			}
+			if formats.ShortID == nil || formats.ShortID.String() != testShortID || formats.PAddress != testPAddress || formats.ShortIDHex != shortIDHex {
+				t.Errorf("expected short id %s, %s and %s, got %v, %s and %s", testShortID, testPAddress, shortIDHex, formats.ShortID, formats.PAddress, formats.ShortIDHex)
+			}
+		})
+	}
+}
+
+func TestConvertAddressShortIDHexRoundTrip(t *testing.T) {
+	formats, err := UtilsNoLog.ConvertAddress(testShortID, AddressInputAuto, constants.LocalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// printed hex short id is converted back as short id, not evm address
+	converted, err := UtilsNoLog.ConvertAddress(formats.ShortIDHex, AddressInputAuto, constants.LocalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if converted.InputFormat != AddressInputShortID || *converted.ShortID != *formats.ShortID {
+		t.Errorf("expected short id %s, got %s %v", formats.ShortID, converted.InputFormat, converted.ShortID)
+	}
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"caminoclient/internal/errkind"
+)
+
+var errInvalidAmount = errkind.New(errkind.InvalidInput, "invalid amount")
+
+// ParseDecimalAmount parses human-readable decimal amount (e.g. "1.5")
+// into integer amount of smallest token units with given decimals.
+func ParseDecimalAmount(amountStr string, decimals uint8) (*big.Int, error) {
+	amountStr = strings.TrimSpace(amountStr)
+	intPart, fracPart, _ := strings.Cut(amountStr, ".")
+	// sign isn't accepted, so "-0" isn't parsed as zero
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return nil, fmt.Errorf("%w: %q", errInvalidAmount, amountStr)
+	}
+	if len(fracPart) > int(decimals) {
+		return nil, fmt.Errorf("%w: %q has more than %d decimals", errInvalidAmount, amountStr, decimals)
+	}
+	fracPart += strings.Repeat("0", int(decimals)-len(fracPart))
+
+	amount, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errInvalidAmount, amountStr)
+	}
+	return amount, nil
+}
+
+func isDigits(str string) bool {
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatDecimalAmount formats integer amount of smallest token units
+// as human-readable decimal amount with given decimals.
+func FormatDecimalAmount(amount *big.Int, decimals uint8) string {
+	if decimals == 0 {
+		return amount.String()
+	}
+	str := new(big.Int).Abs(amount).String()
+	if len(str) <= int(decimals) {
+		str = strings.Repeat("0", int(decimals)-len(str)+1) + str
+	}
+	intPart, fracPart := str[:len(str)-int(decimals)], strings.TrimRight(str[len(str)-int(decimals):], "0")
+	if amount.Sign() < 0 {
+		intPart = "-" + intPart
+	}
+	if fracPart == "" {
+		return intPart
+	}
+	return intPart + "." + fracPart
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestParseDecimalAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint8
+		expected string
+		err      bool
+	}{
+		{amount: "1.5", decimals: 9, expected: "1500000000"},
+		{amount: " 1 ", decimals: 9, expected: "1000000000"},
+		{amount: "0.000000001", decimals: 9, expected: "1"},
+		{amount: "123456789012345678901234567890", decimals: 18, expected: "123456789012345678901234567890000000000000000000"},
+		{amount: ".5", decimals: 2, expected: "50"},
+		{amount: "5.", decimals: 2, expected: "500"},
+		{amount: "0", decimals: 9, expected: "0"},
+		{amount: "7", decimals: 0, expected: "7"},
+		{amount: "7.", decimals: 0, expected: "7"},
+		{amount: "7.0", decimals: 0, err: true},
+		{amount: "0.0000000001", decimals: 9, err: true},
+		{amount: "1.50", decimals: 1, err: true},
+		{amount: "", decimals: 9, err: true},
+		{amount: ".", decimals: 9, err: true},
+		{amount: "-1", decimals: 9, err: true},
+		{amount: "-0", decimals: 9, err: true},
+		{amount: "-.5", decimals: 9, err: true},
+		{amount: "+1", decimals: 9, err: true},
+		{amount: "1.-5", decimals: 9, err: true},
+		{amount: "1.2.3", decimals: 9, err: true},
+		{amount: "1,5", decimals: 9, err: true},
+		{amount: "1e9", decimals: 9, err: true},
+		{amount: "0x10", decimals: 9, err: true},
+	}
+	for _, tt := range tests {
+		amount, err := ParseDecimalAmount(tt.amount, tt.decimals)
+		if tt.err {
+			if !errors.Is(err, errInvalidAmount) {
+				t.Errorf("%q with %d decimals: expected errInvalidAmount, got %v", tt.amount, tt.decimals, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q with %d decimals: %v", tt.amount, tt.decimals, err)
+			continue
+		}
+		if amount.String() != tt.expected {
+			t.Errorf("%q with %d decimals: expected %s, got %s", tt.amount, tt.decimals, tt.expected, amount)
+		}
+	}
+}
+
+func TestFormatDecimalAmount(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		decimals uint8
+		expected string
+	}{
+		{amount: 1_500_000_000, decimals: 9, expected: "1.5"},
+		{amount: 1_000_000_000, decimals: 9, expected: "1"},
+		{amount: 1, decimals: 9, expected: "0.000000001"},
+		{amount: 0, decimals: 9, expected: "0"},
+		{amount: 7, decimals: 0, expected: "7"},
+		{amount: 70, decimals: 0, expected: "70"},
+		{amount: -1_500_000_000, decimals: 9, expected: "-1.5"},
+		{amount: -1, decimals: 2, expected: "-0.01"},
+	}
+	for _, tt := range tests {
+		if amountStr := FormatDecimalAmount(big.NewInt(tt.amount), tt.decimals); amountStr != tt.expected {
+			t.Errorf("%d with %d decimals: expected %q, got %q", tt.amount, tt.decimals, tt.expected, amountStr)
+		}
+	}
+}
+
+func TestDecimalAmountRoundTrip(t *testing.T) {
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	for _, decimals := range []uint8{0, 1, 6, 9, 18} {
+		for _, amount := range []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(10), big.NewInt(123_456_789), maxUint256} {
+			amountStr := FormatDecimalAmount(amount, decimals)
+			parsed, err := ParseDecimalAmount(amountStr, decimals)
+			if err != nil {
+				t.Errorf("%s with %d decimals formatted as %q: %v", amount, decimals, amountStr, err)
+				continue
+			}
+			if parsed.Cmp(amount) != 0 {
+				t.Errorf("%s with %d decimals formatted as %q parsed as %s", amount, decimals, amountStr, parsed)
+			}
+		}
+	}
+}
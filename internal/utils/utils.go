@@ -2,18 +2,18 @@ package utils
 
 import (
 	"bytes"
+	"caminoclient/internal/errkind"
 	"caminoclient/internal/logger"
 	"encoding/hex"
 	"os"
+	"strings"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	avax_secp256k1 "github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
-	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
-	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
 
 func NewSorting(len int) Sorting {
@@ -101,13 +101,12 @@ func (u *UtilsWithLogger) GenerateKey(networkID uint32, print bool) (*avax_secp2
 		u.logger.Error(err)
 		return nil, "", err
 	}
-	keyAddrStr, err := address.Format("t", constants.GetHRP(networkID), key.Address().Bytes())
+	keyAddrStr, err := address.Format("P", constants.GetHRP(networkID), key.Address().Bytes())
 	if err != nil {
 		u.logger.Error(err)
 		return nil, "", err
 	}
 	if print {
-		u.logger.Infof("key: %s", key.String())
 		u.logger.Infof("addr-id: %s", key.Address())
 		u.logger.Infof("addr: %s", keyAddrStr)
 	}
@@ -137,28 +136,6 @@ func (u *UtilsWithLogger) SignPublicKey(key *avax_secp256k1.PrivateKey, print bo
 	return signature, message, nil
 }
 
-func (u *UtilsWithLogger) UncompressedPublicKeyAddress(bytes []byte) error {
-	pubKey, err := secp256k1.ParsePubKey(bytes)
-	if err != nil {
-		u.logger.Error(err)
-		return err
-	}
-
-	addr, err := ids.ToShortID(hashing.PubkeyBytesToAddress(pubKey.SerializeCompressed()))
-	if err != nil {
-		u.logger.Error(err)
-		return err
-	}
-
-	addrStr, err := address.Format("P", constants.GetHRP(constants.ColumbusID), addr[:])
-	if err != nil {
-		u.logger.Error(err)
-		return err
-	}
-	u.logger.Info(addrStr)
-	return nil
-}
-
 func (u *UtilsWithLogger) DecodeHexString(str string, avax bool) (decodedBytes []byte, err error) {
 	if avax {
 		decodedBytes, err = formatting.Decode(formatting.Hex, str)
@@ -178,11 +155,31 @@ func (u *UtilsWithLogger) ID(idStr string) ids.ID {
 }
 
 func (u *UtilsWithLogger) PrivateKey(keyStr string) *avax_secp256k1.PrivateKey {
+	key, err := u.ParsePrivateKey(keyStr)
+	u.logger.NoError(err)
+	return key
+}
+
+// ParsePrivateKey parses "PrivateKey-..." string, quoted or not
+func (u *UtilsWithLogger) ParsePrivateKey(keyStr string) (*avax_secp256k1.PrivateKey, error) {
+	key, err := ParsePrivateKey(keyStr)
+	if err != nil {
+		u.logger.Error(err)
+		return nil, err
+	}
+	return key, nil
+}
+
+// ParsePrivateKey parses "PrivateKey-..." string, quoted or not
+func ParsePrivateKey(keyStr string) (*avax_secp256k1.PrivateKey, error) {
+	if !strings.HasPrefix(keyStr, "\"") {
+		keyStr = "\"" + keyStr + "\""
+	}
 	key := new(avax_secp256k1.PrivateKey)
-	if err := key.UnmarshalText([]byte("\"" + keyStr + "\"")); err != nil {
-		u.logger.NoError(err)
+	if err := key.UnmarshalText([]byte(keyStr)); err != nil {
+		return nil, errkind.Wrap(errkind.InvalidInput, err)
 	}
-	return key
+	return key, nil
 }
 
 func (u *UtilsWithLogger) PTX(txBytesStr string) *txs.Tx {
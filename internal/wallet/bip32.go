@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// HardenedOffset is added to index of hardened bip-32 derivation path element
+const HardenedOffset uint32 = 0x80000000
+
+var (
+	errInvalidPath     = errkind.New(errkind.InvalidInput, "invalid derivation path")
+	errInvalidChildKey = errors.New("derived key is invalid, next index should be used")
+)
+
+// extendedKey is bip-32 extended private key
+type extendedKey struct {
+	key       [32]byte
+	chainCode [32]byte
+}
+
+func newMasterKey(seed []byte) (*extendedKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	_, _ = mac.Write(seed)
+	return newExtendedKey(mac.Sum(nil))
+}
+
+func newExtendedKey(i []byte) (*extendedKey, error) {
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(i[:32]); overflow || scalar.IsZero() {
+		return nil, errInvalidChildKey
+	}
+	k := &extendedKey{key: scalar.Bytes()}
+	copy(k.chainCode[:], i[32:])
+	return k, nil
+}
+
+func (k *extendedKey) child(index uint32) (*extendedKey, error) {
+	data := make([]byte, 0, 37)
+	if index >= HardenedOffset {
+		data = append(data, 0)
+		data = append(data, k.key[:]...)
+	} else {
+		data = append(data, secp256k1.PrivKeyFromBytes(k.key[:]).PubKey().SerializeCompressed()...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	_, _ = mac.Write(data)
+	i := mac.Sum(nil)
+
+	var childScalar, parentScalar secp256k1.ModNScalar
+	if overflow := childScalar.SetByteSlice(i[:32]); overflow {
+		return nil, errInvalidChildKey
+	}
+	parentScalar.SetBytes(&k.key)
+	childScalar.Add(&parentScalar)
+	if childScalar.IsZero() {
+		return nil, errInvalidChildKey
+	}
+
+	child := &extendedKey{key: childScalar.Bytes()}
+	copy(child.chainCode[:], i[32:])
+	return child, nil
+}
+
+func (k *extendedKey) derive(path []uint32) (*extendedKey, error) {
+	var err error
+	for _, index := range path {
+		k, err = k.child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// ParsePath parses bip-32 derivation path like m/44'/9000'/0'/0/0
+func ParsePath(path string) ([]uint32, error) {
+	elems := strings.Split(strings.TrimSpace(path), "/")
+	if len(elems) == 0 || elems[0] != "m" {
+		return nil, fmt.Errorf("%w: %q", errInvalidPath, path)
+	}
+	indices := make([]uint32, 0, len(elems)-1)
+	for _, elem := range elems[1:] {
+		hardened := strings.HasSuffix(elem, "'") || strings.HasSuffix(elem, "h")
+		if hardened {
+			elem = elem[:len(elem)-1]
+		}
+		index, err := strconv.ParseUint(elem, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", errInvalidPath, path)
+		}
+		if hardened {
+			index += uint64(HardenedOffset)
+		}
+		indices = append(indices, uint32(index))
+	}
+	return indices, nil
+}
+
+// FormatPath formats bip-32 derivation path
+func FormatPath(path []uint32) string {
+	var sb strings.Builder
+	sb.WriteString("m")
+	for _, index := range path {
+		if index >= HardenedOffset {
+			fmt.Fprintf(&sb, "/%d'", index-HardenedOffset)
+		} else {
+			fmt.Fprintf(&sb, "/%d", index)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,94 @@
+package wallet
+
+import (
+	"strings"
+
+	"caminoclient/internal/errkind"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// AvaxAccountPath is bip-44 account path used by camino wallet for P and X chains,
+	// addresses are derived as AvaxAccountPath/0/i
+	AvaxAccountPath = "m/44'/9000'/0'"
+	// EVMAccountPath is bip-44 ethereum account path used for C-Chain,
+	// addresses are derived as EVMAccountPath/0/i
+	EVMAccountPath = "m/44'/60'/0'"
+
+	// DefaultMnemonicBits is entropy size of generated 24 words mnemonic
+	DefaultMnemonicBits = 256
+)
+
+var errInvalidMnemonic = errkind.New(errkind.InvalidInput, "invalid mnemonic")
+
+// NewMnemonic generates new bip-39 mnemonic with entropy of given bit size
+func NewMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// HDWallet derives keys from bip-39 mnemonic
+type HDWallet struct {
+	master *extendedKey
+}
+
+// NewHDWallet imports bip-39 mnemonic with optional passphrase
+func NewHDWallet(mnemonic, passphrase string) (*HDWallet, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errInvalidMnemonic
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	master, err := newMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	return &HDWallet{master: master}, nil
+}
+
+// DeriveKey derives key with bip-32 path, e.g. m/44'/9000'/0'/0/0
+func (w *HDWallet) DeriveKey(path string) (*secp256k1.PrivateKey, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	extKey, err := w.master.derive(indices)
+	if err != nil {
+		return nil, err
+	}
+	return (&secp256k1.Factory{}).ToPrivateKey(extKey.key[:])
+}
+
+// AvaxKey derives P-Chain key with index from external chain of avax account
+func (w *HDWallet) AvaxKey(index uint32) (*secp256k1.PrivateKey, error) {
+	return w.DeriveKey(AvaxPath(index))
+}
+
+// EVMKey derives C-Chain key with index from external chain of ethereum account
+func (w *HDWallet) EVMKey(index uint32) (*secp256k1.PrivateKey, error) {
+	return w.DeriveKey(EVMPath(index))
+}
+
+func AvaxPath(index uint32) string {
+	return FormatPath(append(mustParsePath(AvaxAccountPath), 0, index))
+}
+
+func EVMPath(index uint32) string {
+	return FormatPath(append(mustParsePath(EVMAccountPath), 0, index))
+}
+
+func mustParsePath(path string) []uint32 {
+	indices, err := ParsePath(path)
+	if err != nil {
+		panic(err)
+	}
+	return indices
+}
@@ -0,0 +1,79 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestNewHDWalletSeed(t *testing.T) {
+	// bip-39 test vector with passphrase "TREZOR"
+	seed, err := hex.DecodeString("c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := newMasterKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// extra whitespace is normalized
+	hdWallet, err := NewHDWallet("  "+testMnemonic+"\n", "TREZOR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *hdWallet.master != *expected {
+		t.Errorf("master key doesn't match bip-39 seed")
+	}
+}
+
+func TestNewHDWalletInvalidMnemonic(t *testing.T) {
+	for _, mnemonic := range []string{
+		"",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon notaword",
+	} {
+		if _, err := NewHDWallet(mnemonic, ""); !errors.Is(err, errInvalidMnemonic) {
+			t.Errorf("%q: expected errInvalidMnemonic, got %v", mnemonic, err)
+		}
+	}
+}
+
+func TestHDWalletAddresses(t *testing.T) {
+	hdWallet, err := NewHDWallet(testMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avaxKey, err := hdWallet.AvaxKey(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path := AvaxPath(0); path != "m/44'/9000'/0'/0/0" {
+		t.Errorf("avax path %s", path)
+	}
+	avaxAddr := avaxKey.Address()
+	pAddr, err := address.Format("P", "camino", avaxAddr[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "P-camino1p9575chzhvcwvmvzaqh7yeld76r3af0hxc8n0e"; pAddr != expected {
+		t.Errorf("P-Chain address %s, expected %s", pAddr, expected)
+	}
+
+	evmKey, err := hdWallet.EVMKey(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path := EVMPath(0); path != "m/44'/60'/0'/0/0" {
+		t.Errorf("evm path %s", path)
+	}
+	evmAddr := crypto.PubkeyToAddress(*evmKey.PublicKey().ToECDSA())
+	if expected := "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"; evmAddr.Hex() != expected {
+		t.Errorf("C-Chain address %s, expected %s", evmAddr.Hex(), expected)
+	}
+}
@@ -2,11 +2,9 @@ package main
 
 import (
 	"caminoclient/cmd"
-	"log"
+	"os"
 )
 
 func main() {
-	if err := cmd.Execute(); err != nil {
-		log.Fatalln(err)
-	}
+	os.Exit(cmd.Execute())
 }
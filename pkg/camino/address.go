@@ -0,0 +1,28 @@
+package camino
+
+import "caminoclient/internal/utils"
+
+// AddressFormats are all representations of address for one network.
+// Fields that can't be derived from input (e.g. evm address from short id) are empty.
+type AddressFormats = utils.AddressFormats
+
+// AddressInputFormat is format of ConvertAddress input
+type AddressInputFormat = utils.AddressInputFormat
+
+// Formats of ConvertAddress input
+const (
+	// AddressInputAuto detects input format. 32 bytes hex isn't detected, it can be private key or hash,
+	// and 0x 40 hex chars are evm address, plain hex short id must be given explicitly.
+	AddressInputAuto       = utils.AddressInputAuto
+	AddressInputPrivateKey = utils.AddressInputPrivateKey
+	AddressInputPublicKey  = utils.AddressInputPublicKey
+	AddressInputShortID    = utils.AddressInputShortID
+	AddressInputBech32     = utils.AddressInputBech32
+	AddressInputEVM        = utils.AddressInputEVM
+)
+
+// ConvertAddress converts input of given format into all address representations for network.
+// Input can be short id, bech32 address of network, hex short id, public key, private key or evm 0x address.
+func ConvertAddress(input string, inputFormat AddressInputFormat, networkID uint32) (*AddressFormats, error) {
+	return utils.UtilsNoLog.ConvertAddress(input, inputFormat, networkID)
+}
@@ -0,0 +1,501 @@
+package camino
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"caminoclient/internal/audit"
+	"caminoclient/internal/logger"
+	"caminoclient/internal/node"
+	"caminoclient/internal/node_client"
+	"caminoclient/internal/policy"
+
+	"github.com/ava-labs/avalanchego/ids"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/platformvm/dac"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/interfaces"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Client is camino node client. It builds and signs P-Chain and C-Chain txs,
+// issues them and waits until they are decided. Txs are signed by Signers,
+// so keys can be kept outside of client, e.g. in hardware wallet.
+// Client is safe for concurrent use.
+type Client struct {
+	client *node.Client
+}
+
+// Logger is logger, that client logs its progress and errors with. *zap.SugaredLogger implements it.
+// Private keys and mnemonics are masked in logged messages.
+type Logger = logger.BasicLogger
+
+// SigningPolicy authorizes every tx signing of client
+type SigningPolicy = policy.Engine
+
+// AuditLog records every tx signed and issued by client
+type AuditLog = audit.Log
+
+// Config of client
+type Config struct {
+	// URIs of nodes of the same network, in order of preference.
+	// If node fails, client switches to the next one.
+	URIs []string
+	// Timeout of single rpc call, zero means no timeout
+	Timeout time.Duration
+	// MaxRetries of failed idempotent calls, zero disables retries
+	MaxRetries int
+	// RetryBackoff is delay before first retry, doubled for each next one
+	RetryBackoff time.Duration
+	// RateLimit of rpc calls per second, zero means no limit
+	RateLimit float64
+
+	// Logger is optional, client doesn't log without it
+	Logger Logger
+	// SigningPolicy is optional, client signs any tx without it
+	SigningPolicy *SigningPolicy
+	// AuditLog is optional, signed and issued txs aren't recorded without it
+	AuditLog *AuditLog
+}
+
+// NewClient connects to node and returns client of its network
+func NewClient(ctx context.Context, cfg *Config) (*Client, error) {
+	clientLogger := logger.NewLoggerFromBasic(&logger.NoLog)
+	if cfg.Logger != nil {
+		clientLogger = logger.NewLoggerFromBasic(cfg.Logger)
+	}
+	client, err := node.NewClient(ctx, node_client.Config{
+		URIs:         cfg.URIs,
+		Timeout:      cfg.Timeout,
+		MaxRetries:   cfg.MaxRetries,
+		RetryBackoff: cfg.RetryBackoff,
+		RateLimit:    cfg.RateLimit,
+	}, clientLogger)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.SigningPolicy != nil {
+		client.SetSigningPolicy(cfg.SigningPolicy)
+	}
+	if cfg.AuditLog != nil {
+		client.SetAuditLog(cfg.AuditLog)
+	}
+	return &Client{client: client}, nil
+}
+
+// LoadSigningPolicy reads signing policy json file
+func LoadSigningPolicy(path string) (*SigningPolicy, error) {
+	return policy.LoadEngine(path)
+}
+
+// OpenAuditLog opens audit log file, which entries are recorded with operator
+func OpenAuditLog(path, operator string) (*AuditLog, error) {
+	return audit.Open(path, operator)
+}
+
+// NetworkID returns id of network the client is connected to
+func (c *Client) NetworkID() uint32 {
+	return c.client.NetworkID()
+}
+
+// HRP returns human-readable part of bech32 addresses for network the client is connected to
+func (c *Client) HRP() string {
+	return c.client.HRP()
+}
+
+// IsProductionNetwork returns true, if client is connected to camino mainnet
+func (c *Client) IsProductionNetwork() bool {
+	return c.client.IsProductionNetwork()
+}
+
+// GetPBalance returns P-Chain balance of address
+func (c *Client) GetPBalance(ctx context.Context, addr ids.ShortID) (*PBalance, error) {
+	return c.client.GetPBalance(ctx, addr)
+}
+
+// GetCBalance returns C-Chain balance of address in wei
+func (c *Client) GetCBalance(ctx context.Context, addr common.Address) (*big.Int, error) {
+	return c.client.GetCBalance(ctx, addr)
+}
+
+// GetMultisigAlias fetches alias definition and detects which of its owners are aliases themselves
+func (c *Client) GetMultisigAlias(ctx context.Context, aliasID ids.ShortID) (*MultisigAliasInfo, error) {
+	return c.client.GetMultisigAlias(ctx, aliasID)
+}
+
+// NewPUTXOPool creates pool with all unlocked avax utxos of key
+func (c *Client) NewPUTXOPool(ctx context.Context, key Signer) (*PUTXOPool, error) {
+	return c.client.NewPUTXOPool(ctx, key)
+}
+
+// TransferTx creates BaseTx, that sends avax from fundsKey unlocked utxos to recipients
+func (c *Client) TransferTx(
+	ctx context.Context,
+	recipients []*TransferRecipient,
+	memo []byte,
+	fundsKey Signer,
+	opts ...PSpendOption,
+) (*PTxResult, error) {
+	return c.client.TransferTx(ctx, recipients, memo, fundsKey, opts...)
+}
+
+// PExportTx creates P-Chain ExportTx, that sends amount from fundsKey unlocked utxos
+// to recipientAddr on targetChain. Exported funds must be imported on targetChain.
+func (c *Client) PExportTx(
+	ctx context.Context,
+	amount uint64,
+	recipientAddr ids.ShortID,
+	fundsKey Signer,
+	targetChain string,
+	opts ...PSpendOption,
+) (*PTxResult, error) {
+	return c.client.PExportTx(ctx, amount, recipientAddr, fundsKey, targetChain, opts...)
+}
+
+// PImportTx creates P-Chain ImportTx, that imports key atomic utxos exported from sourceChain
+// to key address, fee is deducted from imported amount.
+// If sourceTxID isn't empty, only outputs of that tx are imported.
+func (c *Client) PImportTx(ctx context.Context, sourceChain string, sourceTxID ids.ID, key Signer) (*PTxResult, error) {
+	return c.client.PImportTx(ctx, sourceChain, sourceTxID, key)
+}
+
+// MsigAliasTx creates tx that defines new multisig alias of addrs with threshold
+func (c *Client) MsigAliasTx(
+	ctx context.Context,
+	addrs []string,
+	threshold uint32,
+	fundsKey Signer,
+	opts ...PSpendOption,
+) (*MsigAliasTxResult, error) {
+	return c.client.MsigAliasTx(ctx, addrs, threshold, fundsKey, opts...)
+}
+
+// UpdateMsigAliasTx creates tx that replaces owners and threshold of existing alias.
+// Tx must be authorized by current alias owners: ownerKeys must contain
+// at least current threshold number of direct (not nested alias) owner keys.
+func (c *Client) UpdateMsigAliasTx(
+	ctx context.Context,
+	aliasID ids.ShortID,
+	addrs []string,
+	threshold uint32,
+	fundsKey Signer,
+	ownerKeys []Signer,
+	opts ...PSpendOption,
+) (*PTxResult, error) {
+	return c.client.UpdateMsigAliasTx(ctx, aliasID, addrs, threshold, fundsKey, ownerKeys, opts...)
+}
+
+// AddressStateTx creates tx that sets or removes address state bit, authorized by executor.
+// Executor can be multisig alias, see Authority.
+func (c *Client) AddressStateTx(
+	ctx context.Context,
+	address ids.ShortID,
+	state as.AddressStateBit,
+	remove bool,
+	fundsKey Signer,
+	executor *Authority,
+	opts ...PSpendOption,
+) (*PTxResult, error) {
+	return c.client.AddressStateTx(ctx, address, state, remove, fundsKey, executor, opts...)
+}
+
+// ProposalTx creates tx that adds dac proposal, authorized by proposer.
+// Proposer can be multisig alias, see Authority.
+func (c *Client) ProposalTx(
+	ctx context.Context,
+	proposal dac.Proposal,
+	fundsKey Signer,
+	proposer *Authority,
+	opts ...PSpendOption,
+) (*PTxResult, error) {
+	return c.client.ProposalTx(ctx, proposal, fundsKey, proposer, opts...)
+}
+
+// VoteTx creates tx that votes for proposal option, authorized by voter.
+// Voter can be multisig alias, see Authority.
+func (c *Client) VoteTx(
+	ctx context.Context,
+	proposalID ids.ID,
+	optionIndex uint32,
+	fundsKey Signer,
+	voter *Authority,
+	opts ...PSpendOption,
+) (*PTxResult, error) {
+	return c.client.VoteTx(ctx, proposalID, optionIndex, fundsKey, voter, opts...)
+}
+
+// EVMTx creates C-Chain ExportTx, that exports amount from fundsKey evm address to recipientAddr on targetChain
+func (c *Client) EVMTx(
+	ctx context.Context,
+	amountToExport uint64,
+	recipientAddr ids.ShortID,
+	fundsKey Signer,
+	targetChain string,
+) (*CTxResult, error) {
+	return c.client.EVMTx(ctx, amountToExport, recipientAddr, fundsKey, targetChain)
+}
+
+// EVMExportTx creates C-Chain ExportTx, that exports avax to recipients on targetChain.
+// Recipients can be multisig owners with threshold and locktime, e.g. owners of msig alias.
+// Funds are taken from fundsKeys evm addresses in given order, every used address is tx input,
+// fee is recalculated for every added input and its signature.
+func (c *Client) EVMExportTx(
+	ctx context.Context,
+	recipients []*TransferRecipient,
+	fundsKeys []Signer,
+	targetChain string,
+) (*CTxResult, error) {
+	return c.client.EVMExportTx(ctx, recipients, fundsKeys, targetChain)
+}
+
+// CImportTx creates C-Chain ImportTx, that imports key atomic utxos exported from sourceChain
+// to recipientAddr evm balance, fee is deducted from imported amount.
+// If sourceTxID isn't empty, only outputs of that tx are imported.
+func (c *Client) CImportTx(
+	ctx context.Context,
+	sourceChain string,
+	sourceTxID ids.ID,
+	key Signer,
+	recipientAddr common.Address,
+) (*CTxResult, error) {
+	return c.client.CImportTx(ctx, sourceChain, sourceTxID, key, recipientAddr)
+}
+
+// NewPartialPTx returns partial tx of result built with authority, which signers have no keys
+func (c *Client) NewPartialPTx(result *PTxResult) (*PartialPTx, error) {
+	return c.client.NewPartialPTx(result)
+}
+
+// SignPartialPTx adds signatures of keys, that are missing signers of tx
+func (c *Client) SignPartialPTx(ctx context.Context, partial *PartialPTx, keys []Signer) error {
+	return c.client.SignPartialPTx(ctx, partial, keys)
+}
+
+// SignedPTx returns tx of partial tx, if it's fully signed
+func (c *Client) SignedPTx(partial *PartialPTx) (*pTxs.Tx, error) {
+	return c.client.SignedPTx(partial)
+}
+
+// SummarizePTx describes signed P-Chain tx
+func (c *Client) SummarizePTx(txBytes []byte) (*TxSummary, error) {
+	return c.client.SummarizePTx(txBytes)
+}
+
+// SummarizeCTx describes signed C-Chain atomic tx
+func (c *Client) SummarizeCTx(txBytes []byte) (*TxSummary, error) {
+	return c.client.SummarizeCTx(txBytes)
+}
+
+// IssuePTx issues signed P-Chain tx, recording it to audit log, if client has it
+func (c *Client) IssuePTx(ctx context.Context, txBytes []byte) error {
+	return c.client.IssuePTx(ctx, txBytes)
+}
+
+// IssueCTx issues signed C-Chain atomic tx, recording it to audit log, if client has it
+func (c *Client) IssueCTx(ctx context.Context, txBytes []byte) error {
+	return c.client.IssueCTx(ctx, txBytes)
+}
+
+// GetPTxStatus returns status of P-Chain tx and reason of its rejection, if it was rejected
+func (c *Client) GetPTxStatus(ctx context.Context, txID ids.ID) (status.Status, string, error) {
+	return c.client.GetPTxStatus(ctx, txID)
+}
+
+// GetCTxStatus returns status of C-Chain atomic tx
+func (c *Client) GetCTxStatus(ctx context.Context, txID ids.ID) (evm.Status, error) {
+	return c.client.GetCTxStatus(ctx, txID)
+}
+
+// WaitForPTx polls node until P-Chain tx is decided, returns error if it wasn't committed
+func (c *Client) WaitForPTx(ctx context.Context, txID ids.ID) error {
+	return c.client.WaitForPTx(ctx, txID)
+}
+
+// WaitForCTx polls node until C-Chain atomic tx is decided, returns error if it wasn't accepted
+func (c *Client) WaitForCTx(ctx context.Context, txID ids.ID) error {
+	return c.client.WaitForCTx(ctx, txID)
+}
+
+// SendEVMTx creates, signs and sends C-Chain tx with dynamic fee.
+// Nonces are tracked locally, so several consecutive sends from the same key
+// don't have to wait for previous txs acceptance.
+func (c *Client) SendEVMTx(
+	ctx context.Context,
+	key Signer,
+	to *common.Address,
+	value *big.Int,
+	data []byte,
+) (*types.Transaction, error) {
+	return c.client.SendEVMTx(ctx, key, to, value, data)
+}
+
+// SendEVMTxWithNonce creates, signs and sends C-Chain tx with dynamic fee and given nonce,
+// which must be reserved with ReserveEVMNonces.
+func (c *Client) SendEVMTxWithNonce(
+	ctx context.Context,
+	key Signer,
+	nonce uint64,
+	to *common.Address,
+	value *big.Int,
+	data []byte,
+) (*types.Transaction, error) {
+	return c.client.SendEVMTxWithNonce(ctx, key, nonce, to, value, data)
+}
+
+// EstimateEVMTx returns gas, that C-Chain tx from given address is expected to use,
+// and max fee, that would be paid for it
+func (c *Client) EstimateEVMTx(
+	ctx context.Context,
+	from common.Address,
+	to *common.Address,
+	value *big.Int,
+	data []byte,
+) (*EVMTxEstimate, error) {
+	return c.client.EstimateEVMTx(ctx, from, to, value, data)
+}
+
+// WaitForEVMReceipt polls node until receipt of tx with given hash is available
+func (c *Client) WaitForEVMReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return c.client.WaitForEVMReceipt(ctx, txHash)
+}
+
+// ReserveEVMNonces reserves count consecutive nonces for address and returns the first one.
+// Nonces of txs that were sent by this client, but not yet accepted, are skipped.
+// Reserved nonce must be released with ReleaseEVMNonce, if tx with it wasn't sent.
+// Reserved nonce is held until its tx is sent, it's never considered dropped before that.
+func (c *Client) ReserveEVMNonces(ctx context.Context, addr common.Address, count uint64) (uint64, error) {
+	return c.client.ReserveEVMNonces(ctx, addr, count)
+}
+
+// ReleaseEVMNonce gives back reserved nonce that wasn't used,
+// so it will be reserved again by next tx
+func (c *Client) ReleaseEVMNonce(addr common.Address, nonce uint64) {
+	c.client.ReleaseEVMNonce(addr, nonce)
+}
+
+// ResetEVMNonces drops nonce state of address tracked by this client,
+// so next reservation will rely only on node state
+func (c *Client) ResetEVMNonces(addr common.Address) {
+	c.client.ResetEVMNonces(addr)
+}
+
+// GetEVMNonceState returns nonce state of address according to node and this client
+func (c *Client) GetEVMNonceState(ctx context.Context, addr common.Address) (*EVMNonceState, error) {
+	return c.client.GetEVMNonceState(ctx, addr)
+}
+
+// FilterLogsChunked queries C-Chain logs in block ranges of at most chunkSize blocks,
+// passing each chunk result to handle. If node rejects range (e.g. too many results),
+// chunk is split in half until it consists of a single block.
+// Nil query.ToBlock means latest accepted block.
+func (c *Client) FilterLogsChunked(
+	ctx context.Context,
+	query interfaces.FilterQuery,
+	chunkSize uint64,
+	handle func([]types.Log) error,
+) error {
+	return c.client.FilterLogsChunked(ctx, query, chunkSize, handle)
+}
+
+// ERC20Info returns erc20 token metadata
+func (c *Client) ERC20Info(ctx context.Context, token common.Address) (*ERC20Info, error) {
+	return c.client.ERC20Info(ctx, token)
+}
+
+// ERC20Decimals returns number of decimals of erc20 token amounts
+func (c *Client) ERC20Decimals(ctx context.Context, token common.Address) (uint8, error) {
+	return c.client.ERC20Decimals(ctx, token)
+}
+
+// ERC20Balance returns erc20 token balance of owner in smallest token units
+func (c *Client) ERC20Balance(ctx context.Context, token, owner common.Address) (*big.Int, error) {
+	return c.client.ERC20Balance(ctx, token, owner)
+}
+
+// ERC20Allowance returns amount of owner erc20 tokens, that spender is allowed to spend
+func (c *Client) ERC20Allowance(ctx context.Context, token, owner, spender common.Address) (*big.Int, error) {
+	return c.client.ERC20Allowance(ctx, token, owner, spender)
+}
+
+// ERC20Transfer sends erc20 transfer tx without waiting for its receipt
+func (c *Client) ERC20Transfer(
+	ctx context.Context,
+	token, to common.Address,
+	amount *big.Int,
+	key Signer,
+) (*types.Transaction, error) {
+	return c.client.ERC20Transfer(ctx, token, to, amount, key)
+}
+
+// ERC20Approve sends erc20 approve tx without waiting for its receipt
+func (c *Client) ERC20Approve(
+	ctx context.Context,
+	token, spender common.Address,
+	amount *big.Int,
+	key Signer,
+) (*types.Transaction, error) {
+	return c.client.ERC20Approve(ctx, token, spender, amount, key)
+}
+
+// EstimateERC20Transfer estimates gas and max fee of erc20 transfer tx from given address
+func (c *Client) EstimateERC20Transfer(
+	ctx context.Context,
+	token, from, to common.Address,
+	amount *big.Int,
+) (*EVMTxEstimate, error) {
+	return c.client.EstimateERC20Transfer(ctx, token, from, to, amount)
+}
+
+// EstimateERC20Approve estimates gas and max fee of erc20 approve tx from given address
+func (c *Client) EstimateERC20Approve(
+	ctx context.Context,
+	token, owner, spender common.Address,
+	amount *big.Int,
+) (*EVMTxEstimate, error) {
+	return c.client.EstimateERC20Approve(ctx, token, owner, spender, amount)
+}
+
+// WaitForERC20TxResult waits for erc20 tx receipt and decodes events emitted by token
+func (c *Client) WaitForERC20TxResult(ctx context.Context, token common.Address, txHash common.Hash) (*ERC20TxResult, error) {
+	return c.client.WaitForERC20TxResult(ctx, token, txHash)
+}
+
+// DeployContract sends contract creation tx with abi-encoded constructor args and waits for its receipt
+func (c *Client) DeployContract(
+	ctx context.Context,
+	artifact *ContractArtifact,
+	constructorArgs []interface{},
+	key Signer,
+) (*ContractDeployment, error) {
+	return c.client.DeployContract(ctx, artifact, constructorArgs, key)
+}
+
+// EstimateContractDeploy estimates gas and max fee of contract creation tx from given address
+func (c *Client) EstimateContractDeploy(
+	ctx context.Context,
+	artifact *ContractArtifact,
+	constructorArgs []interface{},
+	from common.Address,
+) (*EVMTxEstimate, error) {
+	return c.client.EstimateContractDeploy(ctx, artifact, constructorArgs, from)
+}
+
+// ListHDAccounts returns first count accounts of hd wallet with their balances
+func (c *Client) ListHDAccounts(ctx context.Context, hdWallet *HDWallet, count uint32) ([]*HDAccount, error) {
+	return c.client.ListHDAccounts(ctx, hdWallet, count)
+}
+
+// DiscoverHDAccounts scans accounts of hd wallet until gapLimit consecutive unused accounts are found
+// and returns accounts up to the last used one
+func (c *Client) DiscoverHDAccounts(ctx context.Context, hdWallet *HDWallet, gapLimit uint32) ([]*HDAccount, error) {
+	return c.client.DiscoverHDAccounts(ctx, hdWallet, gapLimit)
+}
+
+// GetHDAccount derives account with given index and fetches its balances
+func (c *Client) GetHDAccount(ctx context.Context, hdWallet *HDWallet, index uint32) (*HDAccount, error) {
+	return c.client.GetHDAccount(ctx, hdWallet, index)
+}
@@ -0,0 +1,100 @@
+package camino
+
+import (
+	"fmt"
+	"math/big"
+
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/node"
+	"caminoclient/internal/utils"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	pTxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+var errUnknownChain = errkind.New(errkind.InvalidInput, "unknown chain, expected P or C")
+
+// ErrUnknownEvent is returned by DecodeEVMLog, if abi has no event matching log topic
+var ErrUnknownEvent = node.ErrUnknownEvent
+
+// ParseAddress parses bech32 address (with or without chain prefix) or cb58 short id
+func ParseAddress(addrStr string) (ids.ShortID, error) {
+	return utils.ParseAddress(addrStr)
+}
+
+// FormatAddress formats address as bech32 address of chain (P, X or C) on network
+func FormatAddress(chain string, networkID uint32, addr ids.ShortID) (string, error) {
+	return address.Format(chain, constants.GetHRP(networkID), addr[:])
+}
+
+// ParseAmount parses human-readable decimal amount (e.g. "1.5")
+// into integer amount of smallest token units with given decimals
+func ParseAmount(amountStr string, decimals uint8) (*big.Int, error) {
+	return utils.ParseDecimalAmount(amountStr, decimals)
+}
+
+// FormatAmount formats integer amount of smallest token units
+// as human-readable decimal amount with given decimals
+func FormatAmount(amount *big.Int, decimals uint8) string {
+	return utils.FormatDecimalAmount(amount, decimals)
+}
+
+// DecodedTx is signed tx decoded from bytes
+type DecodedTx struct {
+	TxID        ids.ID              `json:"txID"`
+	Unsigned    interface{}         `json:"unsignedTx"`
+	Credentials []verify.Verifiable `json:"credentials"`
+}
+
+// DecodeTx decodes signed P-Chain tx or C-Chain atomic tx
+func DecodeTx(chain string, txBytes []byte) (*DecodedTx, error) {
+	decoded := &DecodedTx{TxID: ids.ID(hashing.ComputeHash256Array(txBytes))}
+	switch chain {
+	case "P":
+		tx, err := pTxs.Parse(pTxs.Codec, txBytes)
+		if err != nil {
+			return nil, errkind.Wrap(errkind.InvalidInput, err)
+		}
+		decoded.Unsigned, decoded.Credentials = tx.Unsigned, tx.Creds
+	case "C":
+		tx := &evm.Tx{}
+		if _, err := evm.Codec.Unmarshal(txBytes, tx); err != nil {
+			return nil, errkind.Wrap(errkind.InvalidInput, err)
+		}
+		decoded.Unsigned, decoded.Credentials = tx.UnsignedAtomicTx, tx.Creds
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnknownChain, chain)
+	}
+	return decoded, nil
+}
+
+// DecodeEVMLog decodes log into event with named fields,
+// returns ErrUnknownEvent if abi has no event matching log topic
+func DecodeEVMLog(contractABI *abi.ABI, log *types.Log) (*DecodedEvent, error) {
+	return node.DecodeEVMLog(contractABI, log)
+}
+
+// ParseABIArgs converts string arguments into go values of types expected by abi packing.
+// Arrays and slices are passed as json arrays, e.g. ["0x...", "0x..."].
+func ParseABIArgs(inputs abi.Arguments, args []string) ([]interface{}, error) {
+	return node.ParseABIArgs(inputs, args)
+}
+
+// ReadContractArtifact reads contract artifact from solc, Hardhat or Foundry json file.
+// If artifact contains several contracts (solc --combined-json), contractName must be provided.
+func ReadContractArtifact(path, contractName string) (*ContractArtifact, error) {
+	return node.ReadContractArtifact(path, contractName)
+}
+
+// ReadContractABI reads contract abi either from plain abi json file
+// or from solc, Hardhat or Foundry artifact.
+func ReadContractABI(path, contractName string) (*abi.ABI, error) {
+	return node.ReadContractABI(path, contractName)
+}
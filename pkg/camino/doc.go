@@ -0,0 +1,35 @@
+// Package camino is Go SDK of camino network client.
+//
+// Client connects to camino node rpc, builds and signs P-Chain and C-Chain txs,
+// issues them and waits until they are decided:
+//
+//	client, err := camino.NewClient(ctx, &camino.Config{URIs: []string{"http://127.0.0.1:9650"}})
+//	if err != nil {
+//		return err
+//	}
+//	key, err := camino.ParsePrivateKey("PrivateKey-...")
+//	if err != nil {
+//		return err
+//	}
+//	result, err := client.TransferTx(ctx, recipients, nil, key)
+//	if err != nil {
+//		return err
+//	}
+//	if err := client.IssuePTx(ctx, result.Tx.Bytes()); err != nil {
+//		return err
+//	}
+//	return client.WaitForPTx(ctx, result.TxID)
+//
+// All client methods, that call node, take context and stop when it's done.
+// Client doesn't log, unless logger is given in config.
+//
+// Errors can be checked for their kind with errors.Is, e.g. errors.Is(err, camino.InsufficientFunds).
+//
+// Tx builders sign with Signer, which is implemented by *PrivateKey and can be implemented
+// by signers, that keep keys elsewhere, e.g. hardware wallet or remote signing service.
+// Txs, that need signatures of other parties, are built with Authority,
+// which Signers have no keys, and completed by them with Client.SignPartialPTx.
+//
+// Messages and eip-712 typed data are signed and verified without client,
+// with SignMessage, VerifyMessage, SignTypedData and VerifyTypedData.
+package camino
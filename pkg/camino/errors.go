@@ -0,0 +1,36 @@
+package camino
+
+import (
+	"caminoclient/internal/errkind"
+	"caminoclient/internal/policy"
+)
+
+// ErrorKind is category of client error, errors.Is(err, kind) reports, if err is of kind
+type ErrorKind = errkind.Kind
+
+// Kinds of client errors
+const (
+	UnknownError      = errkind.Unknown
+	InvalidInput      = errkind.InvalidInput
+	InsufficientFunds = errkind.InsufficientFunds
+	WrongNetwork      = errkind.WrongNetwork
+	RPCUnavailable    = errkind.RPCUnavailable
+	TxRejected        = errkind.TxRejected
+	SignatureMissing  = errkind.SignatureMissing
+)
+
+// ErrSigningDenied is returned, if signing policy denied tx signing. Its reasons are in *SigningDenial.
+var ErrSigningDenied = policy.ErrDenied
+
+// SigningDenial is error with reasons of signing denied by policy
+type SigningDenial = policy.Denial
+
+// ErrorKindOf returns kind of err, UnknownError if it has none
+func ErrorKindOf(err error) ErrorKind {
+	return errkind.Of(err)
+}
+
+// RejectionReason returns reason of tx rejection by node, if err is TxRejected error
+func RejectionReason(err error) string {
+	return errkind.ReasonOf(err)
+}
@@ -0,0 +1,73 @@
+package camino
+
+import (
+	"caminoclient/internal/logger"
+	"caminoclient/internal/signer"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// HashMode defines how message is hashed before signing
+type HashMode = signer.HashMode
+
+// Message hash modes
+const (
+	// HashModeRaw is sha256 of message, same as PrivateKey.Sign
+	HashModeRaw = signer.HashModeRaw
+	// HashModeWallet is sha256 of message prefixed the way camino wallet does it
+	HashModeWallet = signer.HashModeWallet
+	// HashModeEIP191 is keccak256 of message prefixed as in eip-191 personal_sign
+	HashModeEIP191 = signer.HashModeEIP191
+)
+
+type (
+	// RecoveredSigner is signer of message recovered from its signature
+	RecoveredSigner = signer.RecoveredSigner
+	// TypedData is eip-712 typed data: types, primaryType, domain and message
+	TypedData = apitypes.TypedData
+	// TypedDataSignature is eip-712 signature in formats expected by evm contracts
+	TypedDataSignature = signer.TypedDataSignature
+)
+
+// SignMessage signs message hashed according to mode.
+// Signature is [r || s || v], v is 27/28 for eip-191 and 0/1 otherwise.
+func SignMessage(key *PrivateKey, msg []byte, mode HashMode) ([]byte, error) {
+	return signer.NewMsgSignerFromKey(key, &logger.NoLog).SignWithHashMode(msg, mode)
+}
+
+// RecoverMessageSigner recovers signer of message from signature made in given hash mode.
+// P-Chain address of signer is formatted for network with given id.
+func RecoverMessageSigner(msg, sig []byte, mode HashMode, networkID uint32) (*RecoveredSigner, error) {
+	return signer.NewMsgVerifier(&logger.NoLog).Recover(msg, sig, mode, networkID)
+}
+
+// VerifyMessage recovers signer of message and checks it against expected address,
+// which can be bech32 address with any chain prefix, short id or 0x evm address
+func VerifyMessage(msg, sig []byte, mode HashMode, expectedAddr string, networkID uint32) (*RecoveredSigner, bool, error) {
+	return signer.NewMsgVerifier(&logger.NoLog).Verify(msg, sig, mode, expectedAddr, networkID)
+}
+
+// DecodeSignature decodes signature from avax hex with checksum, plain hex or cb58
+func DecodeSignature(sigStr string) ([]byte, error) {
+	return signer.DecodeSignature(sigStr)
+}
+
+// ReadTypedData reads eip-712 typed data from json file
+func ReadTypedData(path string) (*TypedData, error) {
+	return signer.ReadTypedData(path)
+}
+
+// SignTypedData signs eip-712 hash of typed data
+func SignTypedData(key *PrivateKey, typedData *TypedData) (*TypedDataSignature, error) {
+	return signer.NewMsgSignerFromKey(key, &logger.NoLog).SignTypedData(typedData)
+}
+
+// RecoverTypedDataSigner recovers signer of eip-712 typed data from its signature
+func RecoverTypedDataSigner(typedData *TypedData, sig []byte, networkID uint32) (*RecoveredSigner, error) {
+	return signer.NewMsgVerifier(&logger.NoLog).RecoverTypedData(typedData, sig, networkID)
+}
+
+// VerifyTypedData recovers signer of eip-712 typed data and checks it against expected address
+func VerifyTypedData(typedData *TypedData, sig []byte, expectedAddr string, networkID uint32) (*RecoveredSigner, bool, error) {
+	return signer.NewMsgVerifier(&logger.NoLog).VerifyTypedData(typedData, sig, expectedAddr, networkID)
+}
@@ -0,0 +1,39 @@
+package camino
+
+import (
+	"caminoclient/internal/node"
+	"caminoclient/internal/utils"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PrivateKey is secp256k1 private key, that signs txs. It implements Signer.
+type PrivateKey = secp256k1.PrivateKey
+
+// Signer signs tx hashes with key of address. It's implemented by *PrivateKey
+// and can be implemented by signers, that keep keys elsewhere, e.g. in hardware wallet.
+// SignHash returns 65 bytes recoverable signature [r || s || v].
+// Public key is needed to derive C-Chain address of signer.
+type Signer = node.Signer
+
+// NewPrivateKey generates new private key
+func NewPrivateKey() (*PrivateKey, error) {
+	factory := secp256k1.Factory{}
+	return factory.NewPrivateKey()
+}
+
+// ParsePrivateKey parses "PrivateKey-..." string, quoted or not
+func ParsePrivateKey(keyStr string) (*PrivateKey, error) {
+	return utils.ParsePrivateKey(keyStr)
+}
+
+// EVMAddress returns C-Chain address of signer key
+func EVMAddress(signer Signer) common.Address {
+	return node.EVMAddress(signer)
+}
+
+// KeySigners returns keys as signers
+func KeySigners(keys []*PrivateKey) []Signer {
+	return node.KeySigners(keys)
+}
@@ -0,0 +1,86 @@
+package camino
+
+import (
+	"caminoclient/internal/node"
+	"caminoclient/internal/wallet"
+)
+
+// Results of tx builders
+type (
+	// TxResult describes built and signed tx
+	TxResult = node.TxResult
+	// TxOutput is output produced by tx
+	TxOutput = node.TxOutput
+	// PTxResult is built and signed P-Chain tx
+	PTxResult = node.PTxResult
+	// CTxResult is built and signed C-Chain atomic tx
+	CTxResult = node.CTxResult
+	// MsigAliasTxResult is created MultisigAliasTx with alias it defines
+	MsigAliasTxResult = node.MsigAliasTxResult
+	// ERC20TxResult is receipt of erc20 tx with decoded Transfer/Approval events
+	ERC20TxResult = node.ERC20TxResult
+	// TxSummary is human-readable description of signed tx
+	TxSummary = node.TxSummary
+	// PartialPTx is P-Chain tx, that isn't signed by all its signers yet
+	PartialPTx = node.PartialPTx
+	// EVMTxEstimate is gas, that C-Chain tx is expected to use, and max fee paid for it
+	EVMTxEstimate = node.EVMTxEstimate
+)
+
+// Arguments of tx builders
+type (
+	// Authority is address, that authorizes tx as executor, proposer or voter, and keys of its signers
+	Authority = node.Authority
+	// TransferRecipient is destination of P-Chain transfer
+	TransferRecipient = node.TransferRecipient
+	// PSpendOption changes how funds for P-Chain tx are spent
+	PSpendOption = node.PSpendOption
+	// PUTXOPool is set of unlocked P-Chain avax utxos owned by single key, which is spent locally
+	PUTXOPool = node.PUTXOPool
+)
+
+// Chain state
+type (
+	// PBalance is P-Chain balance of address, summed from its utxos
+	PBalance = node.PBalance
+	// MultisigAliasInfo is multisig alias definition fetched from node
+	MultisigAliasInfo = node.MultisigAliasInfo
+	// ERC20Info is erc20 token metadata
+	ERC20Info = node.ERC20Info
+	// EVMNonceState is nonce state of C-Chain address
+	EVMNonceState = node.EVMNonceState
+	// HDAccount is pair of P-Chain and C-Chain addresses derived with the same index
+	HDAccount = node.HDAccount
+	// HDWallet derives keys from bip-39 mnemonic
+	HDWallet = wallet.HDWallet
+)
+
+// Contracts
+type (
+	// ContractArtifact is compiled contract abi and creation bytecode
+	ContractArtifact = node.ContractArtifact
+	// ContractDeployment is result of contract deployment
+	ContractDeployment = node.ContractDeployment
+	// DecodedEvent is evm log decoded with contract abi
+	DecodedEvent = node.DecodedEvent
+)
+
+// KeyAuthority returns authority of single key
+func KeyAuthority(key Signer) *Authority {
+	return node.KeyAuthority(key)
+}
+
+// WithUTXOPool makes tx spend utxos from pool instead of utxos selected by node
+func WithUTXOPool(pool *PUTXOPool) PSpendOption {
+	return node.WithUTXOPool(pool)
+}
+
+// ReadPartialPTx reads partial tx from json file
+func ReadPartialPTx(path string) (*PartialPTx, error) {
+	return node.ReadPartialPTx(path)
+}
+
+// NewHDWallet imports bip-39 mnemonic with optional passphrase
+func NewHDWallet(mnemonic, passphrase string) (*HDWallet, error) {
+	return wallet.NewHDWallet(mnemonic, passphrase)
+}